@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// config holds the generator settings taken from the command line.
+type config struct {
+	Type    string // struct type name
+	Builder string // builder type name, defaults to <Type>PredicateBuilder
+	Pointer bool   // generate predicates over *Type
+}
+
+// field is a struct field selected by its pred tag.
+type field struct {
+	Name string
+	Type string // type as written, e.g. Status
+	Kind string // predeclared type underneath, e.g. string
+	Ops  []string
+}
+
+var (
+	errTypeNotFound = errors.New("type not found")
+	errNotStruct    = errors.New("type is not a struct")
+)
+
+// operators lists the supported tag operators in generation order.
+var operators = []string{"eq", "ne", "gt", "ge", "lt", "le", "in", "notin", "between"}
+
+// orderedTypes are the field types that support every operator. Other than
+// these, only bool is supported, with eq and ne.
+var orderedTypes = map[string]bool{
+	"string": true,
+	"int":    true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true,
+	"byte": true, "rune": true,
+}
+
+// generate parses the Go files in dir and returns the formatted source of
+// the predicates and builder for cfg.Type.
+func generate(dir string, cfg config) ([]byte, error) {
+	pkg, decls, err := parseTypes(dir)
+	if err != nil {
+		return nil, err
+	}
+	st, err := findStruct(decls, cfg.Type, dir)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := collectFields(st, decls)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Type, err)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s: no fields with a pred tag", cfg.Type)
+	}
+
+	if cfg.Builder == "" {
+		cfg.Builder = cfg.Type + "PredicateBuilder"
+	}
+	item := cfg.Type
+	if cfg.Pointer {
+		item = "*" + cfg.Type
+	}
+
+	var buf bytes.Buffer
+	err = fileTemplate.Execute(&buf, map[string]any{
+		"Package": pkg,
+		"Type":    cfg.Type,
+		"Item":    item,
+		"Builder": cfg.Builder,
+		"Fields":  fields,
+	})
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// parseTypes returns the package name and the type declarations of the
+// non-test Go files in dir, keyed by type name.
+func parseTypes(dir string) (string, map[string]ast.Expr, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+	var pkg string
+	decls := make(map[string]ast.Expr)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, err
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		pkg = file.Name.Name
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gen.Specs {
+				ts := s.(*ast.TypeSpec)
+				decls[ts.Name.Name] = ts.Type
+			}
+		}
+	}
+	return pkg, decls, nil
+}
+
+// findStruct returns the struct declaration of typeName among decls.
+func findStruct(decls map[string]ast.Expr, typeName, dir string) (*ast.StructType, error) {
+	expr, ok := decls[typeName]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w in %s", typeName, errTypeNotFound, dir)
+	}
+	st, ok := expr.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", typeName, errNotStruct)
+	}
+	return st, nil
+}
+
+// basicType returns the predeclared type underneath expr, following type
+// definitions and aliases declared in the same package, such as
+// type Status string.
+func basicType(expr ast.Expr, decls map[string]ast.Expr) (string, bool) {
+	// Each step follows one declaration, so a cycle ends the loop.
+	for range len(decls) + 1 {
+		ident, ok := expr.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		if orderedTypes[ident.Name] || ident.Name == "bool" {
+			return ident.Name, true
+		}
+		if expr, ok = decls[ident.Name]; !ok {
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// collectFields returns the tagged fields of st with their operators. Field
+// types are resolved through decls.
+func collectFields(st *ast.StructType, decls map[string]ast.Expr) ([]field, error) {
+	var fields []field
+	for _, f := range st.Fields.List {
+		if f.Tag == nil || len(f.Names) == 0 {
+			continue
+		}
+		tagValue, err := strconv.Unquote(f.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+		tag, ok := reflect.StructTag(tagValue).Lookup("pred")
+		if !ok || tag == "-" || tag == "" {
+			continue
+		}
+
+		kind, ok := basicType(f.Type, decls)
+		if !ok {
+			return nil, fmt.Errorf("field %s: unsupported type %s", f.Names[0].Name, exprString(f.Type))
+		}
+		ops, err := parseOps(tag, kind)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Names[0].Name, err)
+		}
+		for _, name := range f.Names {
+			if name.IsExported() {
+				fields = append(fields, field{Name: name.Name, Type: exprString(f.Type), Kind: kind, Ops: ops})
+			}
+		}
+	}
+	return fields, nil
+}
+
+// parseOps validates a pred tag such as "eq,in" and returns its operators
+// in generation order.
+func parseOps(tag, typ string) ([]string, error) {
+	var ops []string
+	for _, op := range strings.Split(tag, ",") {
+		op = strings.TrimSpace(op)
+		if !slices.Contains(operators, op) {
+			return nil, fmt.Errorf("unknown operator %q", op)
+		}
+		if typ == "bool" && op != "eq" && op != "ne" {
+			return nil, fmt.Errorf("operator %q needs an ordered type, not bool", op)
+		}
+		if !slices.Contains(ops, op) {
+			ops = append(ops, op)
+		}
+	}
+	slices.SortFunc(ops, func(a, b string) int {
+		return slices.Index(operators, a) - slices.Index(operators, b)
+	})
+	return ops, nil
+}
+
+// exprString renders a field type expression for generated code and error
+// messages.
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := format.Node(&buf, token.NewFileSet(), expr); err != nil {
+		return fmt.Sprintf("%T", expr)
+	}
+	return buf.String()
+}
+
+// opNames maps tag operators to the suffix of generated identifiers and the
+// phrase used in doc comments.
+var opNames = map[string][2]string{
+	"eq":      {"Eq", "equals v"},
+	"ne":      {"Ne", "differs from v"},
+	"gt":      {"Gt", "is greater than v"},
+	"ge":      {"Ge", "is greater than or equal to v"},
+	"lt":      {"Lt", "is less than v"},
+	"le":      {"Le", "is less than or equal to v"},
+	"in":      {"In", "equals one of values"},
+	"notin":   {"NotIn", "equals none of values"},
+	"between": {"Between", "lies in the inclusive range [min, max]"},
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"suffix": func(op string) string { return opNames[op][0] },
+	"phrase": func(op string) string { return opNames[op][1] },
+}).Parse(`// Code generated by predicategen -type {{.Type}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"fmt"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+{{range $f := .Fields}}{{range $op := $f.Ops}}
+// {{$.Type}}{{$f.Name}}{{suffix $op}} matches {{$.Type}} values whose {{$f.Name}} {{phrase $op}}.
+{{- if eq $f.Kind "bool"}}
+func {{$.Type}}{{$f.Name}}{{suffix $op}}(v {{$f.Type}}) predicate.Predicate[{{$.Item}}] {
+	return func(item {{$.Item}}) bool {
+		return item.{{$f.Name}} {{if eq $op "eq"}}=={{else}}!={{end}} v
+	}
+}
+{{- else if eq $op "in" "notin"}}
+func {{$.Type}}{{$f.Name}}{{suffix $op}}(values ...{{$f.Type}}) predicate.Predicate[{{$.Item}}] {
+	return predicate.Field(func(item {{$.Item}}) {{$f.Type}} { return item.{{$f.Name}} }).{{suffix $op}}(values...)
+}
+{{- else if eq $op "between"}}
+func {{$.Type}}{{$f.Name}}Between(min, max {{$f.Type}}) predicate.Predicate[{{$.Item}}] {
+	return predicate.Field(func(item {{$.Item}}) {{$f.Type}} { return item.{{$f.Name}} }).Between(min, max)
+}
+{{- else}}
+func {{$.Type}}{{$f.Name}}{{suffix $op}}(v {{$f.Type}}) predicate.Predicate[{{$.Item}}] {
+	return predicate.Field(func(item {{$.Item}}) {{$f.Type}} { return item.{{$f.Name}} }).{{suffix $op}}(v)
+}
+{{- end}}
+{{end}}{{end}}
+// {{.Builder}} builds {{.Type}} predicates from the generated constructors.
+// A combinator switch after conditions were added is ignored, so it cannot
+// change what they mean, and reported by Err.
+type {{.Builder}} struct {
+	predicates []predicate.Predicate[{{.Item}}]
+	combineOp  string // "AND" or "OR"
+	err        error  // first rejected switch, see Err
+}
+
+// New{{.Builder}} creates a builder that combines its conditions with AND.
+func New{{.Builder}}() *{{.Builder}} {
+	return &{{.Builder}}{combineOp: "AND"}
+}
+{{range $f := .Fields}}{{range $op := $f.Ops}}
+// With{{$f.Name}}{{suffix $op}} adds {{$.Type}}{{$f.Name}}{{suffix $op}}.
+{{- if eq $op "in" "notin"}}
+func (b *{{$.Builder}}) With{{$f.Name}}{{suffix $op}}(values ...{{$f.Type}}) *{{$.Builder}} {
+	b.predicates = append(b.predicates, {{$.Type}}{{$f.Name}}{{suffix $op}}(values...))
+	return b
+}
+{{- else if eq $op "between"}}
+func (b *{{$.Builder}}) With{{$f.Name}}Between(min, max {{$f.Type}}) *{{$.Builder}} {
+	b.predicates = append(b.predicates, {{$.Type}}{{$f.Name}}Between(min, max))
+	return b
+}
+{{- else}}
+func (b *{{$.Builder}}) With{{$f.Name}}{{suffix $op}}(v {{$f.Type}}) *{{$.Builder}} {
+	b.predicates = append(b.predicates, {{$.Type}}{{$f.Name}}{{suffix $op}}(v))
+	return b
+}
+{{- end}}
+{{end}}{{end}}
+// UseAND combines the conditions with AND (the default). After conditions
+// were added with OR it is ignored, and Err reports
+// predicate.ErrMixedCombinators.
+func (b *{{.Builder}}) UseAND() *{{.Builder}} {
+	return b.use("AND")
+}
+
+// UseOR combines the conditions with OR. It is rejected like UseAND after
+// conditions were added with AND.
+func (b *{{.Builder}}) UseOR() *{{.Builder}} {
+	return b.use("OR")
+}
+
+func (b *{{.Builder}}) use(op string) *{{.Builder}} {
+	if len(b.predicates) > 0 && op != b.combineOp {
+		if b.err == nil {
+			b.err = fmt.Errorf("%w: Use%s after %d condition(s) were added with %s",
+				predicate.ErrMixedCombinators, op, len(b.predicates), b.combineOp)
+		}
+		return b
+	}
+	b.combineOp = op
+	return b
+}
+
+// Err returns the first rejected combinator switch, wrapping
+// predicate.ErrMixedCombinators, or nil.
+func (b *{{.Builder}}) Err() error {
+	return b.err
+}
+
+// Build returns the combined predicate. Later calls on the builder do not
+// change it. A builder without conditions matches every value.
+func (b *{{.Builder}}) Build() predicate.Predicate[{{.Item}}] {
+	predicates := append([]predicate.Predicate[{{.Item}}](nil), b.predicates...)
+	if len(predicates) == 0 {
+		return func({{.Item}}) bool { return true }
+	}
+	if b.combineOp == "OR" {
+		return func(item {{.Item}}) bool {
+			for _, p := range predicates {
+				if p(item) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return func(item {{.Item}}) bool {
+		for _, p := range predicates {
+			if !p(item) {
+				return false
+			}
+		}
+		return true
+	}
+}
+`))
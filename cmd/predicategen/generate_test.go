@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSource(t *testing.T, src string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "model.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestGenerate(t *testing.T) {
+	dir := writeSource(t, "package model\n\n"+
+		"type Job struct {\n"+
+		"\tName     string `pred:\"in,eq\"`\n"+
+		"\tRetries  int    `pred:\"between\"`\n"+
+		"\tPaused   bool   `pred:\"eq\"`\n"+
+		"\tOwner    string `pred:\"-\"`\n"+
+		"\tinternal string `pred:\"eq\"`\n"+
+		"\tLabels   []string\n"+
+		"\tStatus   Status `pred:\"eq,in\"`\n"+
+		"\tPriority Level  `pred:\"ge\"`\n"+
+		"\tHeld     Flag   `pred:\"ne\"`\n"+
+		"}\n\n"+
+		"type Status string\n\n"+
+		"type Level = Priority\n\n"+
+		"type Priority int\n\n"+
+		"type Flag bool\n")
+
+	src, err := generate(dir, config{Type: "Job", Pointer: true, Builder: "JobFilter"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(src)
+
+	for _, want := range []string{
+		"package model",
+		"func JobNameEq(v string) predicate.Predicate[*Job]",
+		"func JobNameIn(values ...string) predicate.Predicate[*Job]",
+		"func JobRetriesBetween(min, max int) predicate.Predicate[*Job]",
+		"func JobPausedEq(v bool) predicate.Predicate[*Job]",
+		"type JobFilter struct",
+		"func NewJobFilter() *JobFilter",
+		"func (b *JobFilter) WithRetriesBetween(min, max int) *JobFilter",
+		"func (b *JobFilter) Build() predicate.Predicate[*Job]",
+		"func (b *JobFilter) Err() error",
+		"func JobStatusIn(values ...Status) predicate.Predicate[*Job]",
+		"func JobPriorityGe(v Level) predicate.Predicate[*Job]",
+		"func JobHeldNe(v Flag) predicate.Predicate[*Job]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Owner", "internal", "Labels"} {
+		if strings.Contains(out, "Job"+unwanted) {
+			t.Errorf("expected %s to be skipped", unwanted)
+		}
+	}
+	if strings.Index(out, "JobNameEq") > strings.Index(out, "JobNameIn") {
+		t.Error("expected operators in canonical order")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		typ     string
+		want    string
+		wantErr error
+	}{
+		{"missing type", "package m\n", "Job", "", errTypeNotFound},
+		{"not a struct", "package m\ntype Job int\n", "Job", "", errNotStruct},
+		{"unknown operator", "package m\ntype Job struct {\n\tN int `pred:\"like\"`\n}\n", "Job", `field N: unknown operator "like"`, nil},
+		{"ordered op on bool", "package m\ntype Job struct {\n\tB bool `pred:\"gt\"`\n}\n", "Job", `field B: operator "gt" needs an ordered type`, nil},
+		{"unsupported type", "package m\ntype Job struct {\n\tL []int `pred:\"eq\"`\n}\n", "Job", "field L: unsupported type []int", nil},
+		{"foreign type", "package m\nimport \"time\"\ntype Job struct {\n\tD time.Duration `pred:\"gt\"`\n}\n", "Job", "field D: unsupported type time.Duration", nil},
+		{"named struct", "package m\ntype Job struct {\n\tO Owner `pred:\"eq\"`\n}\ntype Owner struct{}\n", "Job", "field O: unsupported type Owner", nil},
+		{"ordered op on named bool", "package m\ntype Job struct {\n\tF Flag `pred:\"lt\"`\n}\ntype Flag bool\n", "Job", `field F: operator "lt" needs an ordered type`, nil},
+		{"no tagged fields", "package m\ntype Job struct {\n\tN int\n}\n", "Job", "no fields with a pred tag", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate(writeSource(t, tt.src), config{Type: tt.typ})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %q", tt.want, err)
+			}
+		})
+	}
+}
+
+// TestGeneratedProductIsCurrent fails when predicate/product_predicates_gen.go
+// is out of date; run go generate ./predicate to refresh it.
+func TestGeneratedProductIsCurrent(t *testing.T) {
+	dir := filepath.Join("..", "..", "predicate")
+	want, err := generate(dir, config{Type: "Product"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "product_predicates_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("product_predicates_gen.go is out of date; run go generate ./predicate")
+	}
+}
@@ -0,0 +1,66 @@
+// Command predicategen generates typed predicate constructors and a fluent
+// builder for a struct type.
+//
+// Fields opt in with a pred struct tag listing the comparisons to generate:
+//
+//	type Product struct {
+//		Category string  `pred:"eq,in"`
+//		Price    float64 `pred:"ge,le,between"`
+//		Tags     []string // no tag: skipped
+//	}
+//
+// Supported operators are eq, ne, gt, ge, lt, le, in, notin and between.
+// Strings and numbers support all of them; bools only support eq and ne.
+// Types declared in the same package on top of these, such as
+// type Status string, are supported like the type underneath.
+//
+// Run it through go generate next to the struct:
+//
+//	//go:generate go run github.com/vdntruong/gopatterns/cmd/predicategen -type Product
+//
+// For Product it writes product_predicates_gen.go with constructors such as
+// ProductCategoryEq and ProductPriceBetween, and a ProductPredicateBuilder
+// with methods such as WithCategoryEq and WithPriceBetween.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("predicategen: ")
+
+	typeName := flag.String("type", "", "struct type to generate predicates for (required)")
+	output := flag.String("output", "", "output file (default <type>_predicates_gen.go)")
+	builder := flag.String("builder", "", "builder type name (default <Type>PredicateBuilder)")
+	pointer := flag.Bool("pointer", false, "generate predicates over *Type instead of Type")
+	flag.Parse()
+
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	cfg := config{Type: *typeName, Builder: *builder, Pointer: *pointer}
+	src, err := generate(dir, cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	name := *output
+	if name == "" {
+		name = filepath.Join(dir, strings.ToLower(*typeName)+"_predicates_gen.go")
+	}
+	if err := os.WriteFile(name, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
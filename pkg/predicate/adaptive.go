@@ -0,0 +1,165 @@
+package predicate
+
+import (
+	"cmp"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// adaptiveConfig holds the settings of an adaptive combinator.
+type adaptiveConfig struct {
+	sampleEvery  int64
+	reorderEvery int64
+}
+
+// AdaptiveOption is a functional option for configuring Adaptive.
+type AdaptiveOption func(*adaptiveConfig)
+
+// WithSampleEvery sets how often an evaluation is measured: every n-th call
+// records which operands passed and how long each took. Measuring costs two
+// clock reads per operand, so sampling keeps the overhead low. Values below
+// 1 are ignored. The default is 16.
+func WithSampleEvery(n int) AdaptiveOption {
+	return func(c *adaptiveConfig) {
+		if n > 0 {
+			c.sampleEvery = int64(n)
+		}
+	}
+}
+
+// WithReorderEvery sets how many calls pass between two reorderings of the
+// operands. Values below 1 are ignored. The default is 1024.
+func WithReorderEvery(n int) AdaptiveOption {
+	return func(c *adaptiveConfig) {
+		if n > 0 {
+			c.reorderEvery = int64(n)
+		}
+	}
+}
+
+// Adaptive returns a copy of an AND or OR predicate that learns the best
+// order in which to evaluate its operands. It samples how often each operand
+// passes and how long it takes, and periodically moves the cheapest, most
+// decisive operands to the front: for AND, those most likely to fail; for OR,
+// those most likely to pass.
+//
+// AND and OR do not depend on operand order, so results are identical as long
+// as the operands are pure. Only the number of operand calls changes. String,
+// Node and Explain keep the declared order. Any other predicate is returned
+// unchanged.
+//
+// The returned predicate is safe for concurrent use. Wrap the finished
+// combinator: passing it to AllOf or AnyOf flattens it into a new node that
+// does not adapt.
+func Adaptive[T any](n Named[T], opts ...AdaptiveOption) Named[T] {
+	if n.node.Op != OpAnd && n.node.Op != OpOr || len(n.operands) < 2 {
+		return n
+	}
+	cfg := adaptiveConfig{sampleEvery: 16, reorderEvery: 1024}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	a := &adaptive[T]{
+		config: cfg,
+		tests:  predicates(n.operands),
+		stop:   n.node.Op == OpOr,
+		stats:  make([]operandStats, len(n.operands)),
+	}
+	order := make([]int, len(n.operands))
+	for i := range order {
+		order[i] = i
+	}
+	a.order.Store(&order)
+
+	n.test = a.test
+	return n
+}
+
+// operandStats accumulates the sampled evaluations of one operand.
+type operandStats struct {
+	evaluated atomic.Int64
+	passed    atomic.Int64
+	nanos     atomic.Int64
+}
+
+type adaptive[T any] struct {
+	config adaptiveConfig
+	tests  []Predicate[T]
+	stop   bool // the operand result that decides the node: false for AND, true for OR
+	stats  []operandStats
+	calls  atomic.Int64
+	order  atomic.Pointer[[]int]
+}
+
+func (a *adaptive[T]) test(item T) bool {
+	call := a.calls.Add(1)
+	order := *a.order.Load()
+
+	var result bool
+	if call%a.config.sampleEvery == 0 {
+		result = a.sample(order, item)
+	} else {
+		result = a.eval(order, item)
+	}
+	if call%a.config.reorderEvery == 0 {
+		a.reorder()
+	}
+	return result
+}
+
+func (a *adaptive[T]) eval(order []int, item T) bool {
+	for _, i := range order {
+		if a.tests[i](item) == a.stop {
+			return a.stop
+		}
+	}
+	return !a.stop
+}
+
+// sample is eval with measurements.
+func (a *adaptive[T]) sample(order []int, item T) bool {
+	for _, i := range order {
+		start := time.Now()
+		passed := a.tests[i](item)
+		stats := &a.stats[i]
+		stats.nanos.Add(int64(time.Since(start)))
+		stats.evaluated.Add(1)
+		if passed {
+			stats.passed.Add(1)
+		}
+		if passed == a.stop {
+			return a.stop
+		}
+	}
+	return !a.stop
+}
+
+// reorder sorts the operands by expected cost per decision: the average
+// time an operand takes divided by the probability that it decides the
+// node. Operands that were never sampled sort first so they get measured.
+func (a *adaptive[T]) reorder() {
+	scores := make([]float64, len(a.stats))
+	for i := range a.stats {
+		stats := &a.stats[i]
+		evaluated := float64(stats.evaluated.Load())
+		if evaluated == 0 {
+			continue
+		}
+		decisive := float64(stats.passed.Load())
+		if !a.stop {
+			decisive = evaluated - decisive
+		}
+		// Laplace smoothing keeps an operand that never decided from
+		// being pushed to the back forever.
+		probability := (decisive + 1) / (evaluated + 2)
+		scores[i] = float64(stats.nanos.Load()) / evaluated / probability
+	}
+
+	order := slices.Clone(*a.order.Load())
+	slices.SortStableFunc(order, func(x, y int) int {
+		return cmp.Compare(scores[x], scores[y])
+	})
+	a.order.Store(&order)
+}
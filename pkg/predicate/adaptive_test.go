@@ -0,0 +1,116 @@
+package predicate
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// slowPass is an expensive operand that always passes and counts its calls.
+func slowPass(calls *atomic.Int64) Named[int] {
+	return Leaf("slow", func(n int) bool {
+		calls.Add(1)
+		return strings.Contains(strings.Repeat("ab", 500)+"key", "key")
+	})
+}
+
+func TestAdaptiveMovesCheapDecisiveOperandFirst(t *testing.T) {
+	var slowCalls atomic.Int64
+	rare := Leaf("rare", func(n int) bool { return n%10 == 0 })
+	pred := Adaptive(AllOf(slowPass(&slowCalls), rare), WithSampleEvery(1), WithReorderEvery(100))
+
+	for n := range 100 {
+		pred.Test(n)
+	}
+	if got := slowCalls.Load(); got != 100 {
+		t.Fatalf("expected the declared order during warm-up, got %d slow calls", got)
+	}
+
+	slowCalls.Store(0)
+	for n := range 1000 {
+		pred.Test(n)
+	}
+	if got := slowCalls.Load(); got > 150 {
+		t.Errorf("expected the slow operand to run only when rare passes, got %d calls", got)
+	}
+	if got := pred.String(); got != "(slow AND rare)" {
+		t.Errorf("expected the declared order in String, got %q", got)
+	}
+}
+
+func TestAdaptiveOrPrefersLikelyPass(t *testing.T) {
+	var slowCalls atomic.Int64
+	never := Leaf("never", func(int) bool { return false })
+	pred := Adaptive(AnyOf(Leaf("slow", func(n int) bool {
+		slowCalls.Add(1)
+		return strings.Contains(strings.Repeat("ab", 500), "key")
+	}), never, Leaf("common", func(n int) bool { return n%10 != 0 })), WithSampleEvery(1), WithReorderEvery(50))
+
+	for n := range 50 {
+		pred.Test(n)
+	}
+	slowCalls.Store(0)
+	for n := range 1000 {
+		pred.Test(n)
+	}
+	if got := slowCalls.Load(); got > 150 {
+		t.Errorf("expected the common operand to decide first, got %d slow calls", got)
+	}
+}
+
+func TestAdaptiveKeepsResults(t *testing.T) {
+	plain := AllOf(
+		Leaf("even", Predicate[int](IsEven)),
+		AnyOf(Leaf("big", GreaterThan(50)), Leaf("small", LessThan(10))),
+		Leaf("not_mult_3", func(n int) bool { return n%3 != 0 }),
+	)
+	adaptive := Adaptive(plain, WithSampleEvery(2), WithReorderEvery(7))
+
+	for n := range 1000 {
+		if got, want := adaptive.Test(n), plain.Test(n); got != want {
+			t.Fatalf("on %d: expected %v, got %v", n, want, got)
+		}
+	}
+
+	or := AnyOf(Leaf("even", Predicate[int](IsEven)), Leaf("big", GreaterThan(50)))
+	adaptiveOr := Adaptive(or, WithSampleEvery(1), WithReorderEvery(3))
+	for n := range 200 {
+		if got, want := adaptiveOr.Test(n), or.Test(n); got != want {
+			t.Fatalf("OR on %d: expected %v, got %v", n, want, got)
+		}
+	}
+}
+
+func TestAdaptiveIgnoresOtherNodes(t *testing.T) {
+	leaf := Leaf("even", Predicate[int](IsEven))
+	if got := Adaptive(leaf.Not()); got.String() != "NOT even" || !got.Test(3) {
+		t.Errorf("expected NOT to be returned unchanged, got %s", got)
+	}
+	if got := Adaptive(AtLeastN(1, leaf, leaf)); !got.Test(2) {
+		t.Error("expected AT LEAST to be returned unchanged")
+	}
+}
+
+func TestAdaptiveConcurrent(t *testing.T) {
+	pred := Adaptive(AllOf(
+		Leaf("even", Predicate[int](IsEven)),
+		Leaf("positive", Predicate[int](IsPositive)),
+		Leaf("big", GreaterThan(100)),
+	), WithSampleEvery(1), WithReorderEvery(10))
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Go(func() {
+			for n := range 500 {
+				item := n*8 + w
+				want := item%2 == 0 && item > 100
+				if got := pred.Test(item); got != want {
+					t.Errorf("on %d: expected %v, got %v", item, want, got)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
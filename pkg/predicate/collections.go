@@ -0,0 +1,99 @@
+package predicate
+
+// Partition splits items into those that satisfy the predicate and those
+// that do not, preserving their order.
+func Partition[T any](items []T, predicate Predicate[T]) (matched, unmatched []T) {
+	for _, item := range items {
+		if predicate(item) {
+			matched = append(matched, item)
+		} else {
+			unmatched = append(unmatched, item)
+		}
+	}
+	return matched, unmatched
+}
+
+// GroupBy groups items by the key returned for each of them. Within a group,
+// items keep their original order.
+func GroupBy[T any, K comparable](items []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range items {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
+
+// TakeWhile returns the longest prefix of items whose elements all satisfy
+// the predicate. The result shares its underlying array with items.
+func TakeWhile[T any](items []T, predicate Predicate[T]) []T {
+	for i, item := range items {
+		if !predicate(item) {
+			return items[:i]
+		}
+	}
+	return items
+}
+
+// DropWhile returns items without the longest prefix whose elements all
+// satisfy the predicate. The result shares its underlying array with items.
+func DropWhile[T any](items []T, predicate Predicate[T]) []T {
+	for i, item := range items {
+		if !predicate(item) {
+			return items[i:]
+		}
+	}
+	return items[len(items):]
+}
+
+// FindLast returns the last element that satisfies the predicate.
+func FindLast[T any](items []T, predicate Predicate[T]) (T, bool) {
+	for i := len(items) - 1; i >= 0; i-- {
+		if predicate(items[i]) {
+			return items[i], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// IndexOf returns the index of the first element that satisfies the
+// predicate, or -1 if there is none.
+func IndexOf[T any](items []T, predicate Predicate[T]) int {
+	for i, item := range items {
+		if predicate(item) {
+			return i
+		}
+	}
+	return -1
+}
+
+// DistinctBy returns the items whose key has not been seen before, keeping
+// the first item for each key.
+func DistinctBy[T any, K comparable](items []T, key func(T) K) []T {
+	seen := make(map[K]struct{})
+	var result []T
+	for _, item := range items {
+		k := key(item)
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Chunk splits items into consecutive slices of size elements; the last one
+// may be shorter. The chunks share their underlying array with items.
+// Chunk panics if size is less than 1.
+func Chunk[T any](items []T, size int) [][]T {
+	if size < 1 {
+		panic("predicate: chunk size must be at least 1")
+	}
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+	for lo := 0; lo < len(items); lo += size {
+		hi := min(lo+size, len(items))
+		chunks = append(chunks, items[lo:hi:hi])
+	}
+	return chunks
+}
@@ -0,0 +1,103 @@
+package predicate
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestPartition(t *testing.T) {
+	matched, unmatched := Partition([]int{1, 2, 3, 4, 5}, IsEven)
+	if !slices.Equal(matched, []int{2, 4}) || !slices.Equal(unmatched, []int{1, 3, 5}) {
+		t.Errorf("expected [2 4] and [1 3 5], got %v and %v", matched, unmatched)
+	}
+
+	matched, unmatched = Partition(nil, IsEven)
+	if matched != nil || unmatched != nil {
+		t.Errorf("expected nil slices for no input, got %v and %v", matched, unmatched)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	groups := GroupBy([]string{"apple", "avocado", "banana", "blueberry", "cherry"}, func(s string) byte { return s[0] })
+	want := map[byte][]string{
+		'a': {"apple", "avocado"},
+		'b': {"banana", "blueberry"},
+		'c': {"cherry"},
+	}
+	if !maps.EqualFunc(groups, want, slices.Equal) {
+		t.Errorf("expected %v, got %v", want, groups)
+	}
+}
+
+func TestTakeDropWhile(t *testing.T) {
+	items := []int{2, 4, 5, 6, 7}
+	tests := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{"take prefix", TakeWhile(items, IsEven), []int{2, 4}},
+		{"take all", TakeWhile(items, IsPositive), items},
+		{"take none", TakeWhile(items, IsOdd), []int{}},
+		{"drop prefix", DropWhile(items, IsEven), []int{5, 6, 7}},
+		{"drop all", DropWhile(items, IsPositive), []int{}},
+		{"drop none", DropWhile(items, IsOdd), items},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, tt.got)
+			}
+		})
+	}
+}
+
+func TestFindLastIndexOf(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	if got, ok := FindLast(items, IsEven); !ok || got != 4 {
+		t.Errorf("expected (4, true), got (%d, %v)", got, ok)
+	}
+	if _, ok := FindLast(items, GreaterThan(5)); ok {
+		t.Error("expected no match")
+	}
+	if got := IndexOf(items, GreaterThan(2)); got != 2 {
+		t.Errorf("expected 2, got %d", got)
+	}
+	if got := IndexOf(items, GreaterThan(5)); got != -1 {
+		t.Errorf("expected -1, got %d", got)
+	}
+}
+
+func TestDistinctBy(t *testing.T) {
+	got := DistinctBy([]string{"Go", "go", "Rust", "GO", "rust", "C"}, func(s string) int { return len(s) })
+	if want := []string{"Go", "Rust", "C"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	chunks := Chunk([]int{1, 2, 3, 4, 5}, 2)
+	want := [][]int{{1, 2}, {3, 4}, {5}}
+	if !slices.EqualFunc(chunks, want, slices.Equal) {
+		t.Errorf("expected %v, got %v", want, chunks)
+	}
+
+	// Appending to a chunk must not overwrite the next one.
+	_ = append(chunks[0], 99)
+	if chunks[1][0] != 3 {
+		t.Errorf("expected chunks to be capped, got %v", chunks)
+	}
+
+	if got := Chunk([]int{}, 3); len(got) != 0 {
+		t.Errorf("expected no chunks, got %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for size 0")
+		}
+	}()
+	Chunk([]int{1}, 0)
+}
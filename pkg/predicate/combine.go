@@ -0,0 +1,132 @@
+package predicate
+
+// AllOf combines named predicates with logical AND. Operands are evaluated in
+// order and evaluation stops at the first failure. Operands that are
+// themselves AND nodes are flattened into the result, so evaluation walks a
+// single list instead of a chain of nested closures. AllOf with a single operand returns it
+// unchanged; AllOf with none is always true.
+func AllOf[T any](operands ...Named[T]) Named[T] {
+	flat := flatten(OpAnd, operands)
+	if len(flat) == 1 {
+		return flat[0]
+	}
+	tests := predicates(flat)
+	return composite(OpAnd, 0, flat, func(item T) bool {
+		for _, test := range tests {
+			if !test(item) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// AnyOf combines named predicates with logical OR. Operands are evaluated in
+// order and evaluation stops at the first success. Operands that are
+// themselves OR nodes are flattened into the result. AnyOf with a single
+// operand returns it unchanged; AnyOf with none is always false.
+func AnyOf[T any](operands ...Named[T]) Named[T] {
+	flat := flatten(OpOr, operands)
+	if len(flat) == 1 {
+		return flat[0]
+	}
+	tests := predicates(flat)
+	return composite(OpOr, 0, flat, func(item T) bool {
+		for _, test := range tests {
+			if test(item) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// NoneOf is satisfied when no operand is. It is equivalent to
+// AnyOf(operands...).Not() and stops at the first success.
+func NoneOf[T any](operands ...Named[T]) Named[T] {
+	return AnyOf(operands...).Not()
+}
+
+// AtLeastN is satisfied when at least n operands are. Evaluation stops as
+// soon as n operands have passed or too few remain to reach n.
+func AtLeastN[T any](n int, operands ...Named[T]) Named[T] {
+	return threshold(OpAtLeast, n, operands)
+}
+
+// ExactlyN is satisfied when exactly n operands are. Evaluation stops as
+// soon as more than n operands have passed or too few remain to reach n.
+func ExactlyN[T any](n int, operands ...Named[T]) Named[T] {
+	return threshold(OpExactly, n, operands)
+}
+
+func threshold[T any](op Op, n int, operands []Named[T]) Named[T] {
+	operands = append([]Named[T](nil), operands...)
+	tests := predicates(operands)
+	return composite(op, n, operands, func(item T) bool {
+		passed := 0
+		for i := 0; ; i++ {
+			if result, done := decide(op, n, passed, i, len(tests)); done {
+				return result
+			}
+			if tests[i](item) {
+				passed++
+			}
+		}
+	})
+}
+
+// decide reports whether the result of a composite node is settled after
+// evaluated of its total operands were tested and passed of them succeeded.
+func decide(op Op, count, passed, evaluated, total int) (result, done bool) {
+	remaining := total - evaluated
+	switch op {
+	case OpAnd:
+		return passed == evaluated, passed < evaluated || remaining == 0
+	case OpOr:
+		return passed > 0, passed > 0 || remaining == 0
+	case OpAtLeast:
+		if passed >= count {
+			return true, true
+		}
+		return false, passed+remaining < count
+	case OpExactly:
+		if passed > count || passed+remaining < count {
+			return false, true
+		}
+		return passed == count, remaining == 0
+	}
+	return false, true
+}
+
+// flatten splices operands of the given kind into a single list.
+func flatten[T any](op Op, operands []Named[T]) []Named[T] {
+	flat := make([]Named[T], 0, len(operands))
+	for _, operand := range operands {
+		if operand.node.Op == op {
+			flat = append(flat, operand.operands...)
+		} else {
+			flat = append(flat, operand)
+		}
+	}
+	return flat
+}
+
+func predicates[T any](operands []Named[T]) []Predicate[T] {
+	tests := make([]Predicate[T], len(operands))
+	for i, operand := range operands {
+		tests[i] = operand.test
+	}
+	return tests
+}
+
+func composite[T any](op Op, count int, operands []Named[T], test Predicate[T]) Named[T] {
+	children := make([]Node, len(operands))
+	for i, operand := range operands {
+		children[i] = operand.node
+	}
+	return Named[T]{
+		node:     Node{Op: op, Count: count, Children: children},
+		test:     test,
+		operands: operands,
+	}
+}
@@ -0,0 +1,143 @@
+package predicate
+
+import (
+	"testing"
+)
+
+// tracked returns a leaf that records how often it is evaluated.
+func tracked(name string, result bool, calls *int) Named[int] {
+	return Leaf(name, func(int) bool {
+		*calls++
+		return result
+	})
+}
+
+func TestAllOfAnyOfFlatten(t *testing.T) {
+	a := Leaf("a", Predicate[int](IsEven))
+	b := Leaf("b", Predicate[int](IsPositive))
+	c := Leaf("c", GreaterThan(5))
+
+	tests := []struct {
+		name     string
+		pred     Named[int]
+		want     string
+		children int
+	}{
+		{"nested all", AllOf(AllOf(a, b), c), "(a AND b AND c)", 3},
+		{"method chain", a.And(b).And(c), "(a AND b AND c)", 3},
+		{"nested any", AnyOf(a, AnyOf(b, c)), "(a OR b OR c)", 3},
+		{"mixed kinds stay nested", AllOf(a, AnyOf(b, c)), "(a AND (b OR c))", 2},
+		{"none flattens any", NoneOf(AnyOf(a, b), c), "NOT (a OR b OR c)", 1},
+		{"single operand", AllOf(a), "a", 0},
+		{"empty all", AllOf[int](), "TRUE", 0},
+		{"empty any", AnyOf[int](), "FALSE", 0},
+		{"at least", AtLeastN(2, a, b, c), "AT LEAST 2 OF (a, b, c)", 3},
+		{"exactly", ExactlyN(1, a, AllOf(b, c)), "EXACTLY 1 OF (a, (b AND c))", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pred.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if got := len(tt.pred.Node().Children); got != tt.children {
+				t.Errorf("expected %d children, got %d", tt.children, got)
+			}
+		})
+	}
+}
+
+func TestCombinatorResults(t *testing.T) {
+	even := Leaf("even", Predicate[int](IsEven))
+	positive := Leaf("positive", Predicate[int](IsPositive))
+	big := Leaf("big", GreaterThan(5))
+
+	tests := []struct {
+		name string
+		pred Named[int]
+		in   int
+		want bool
+	}{
+		{"all true", AllOf(even, positive, big), 8, true},
+		{"all false", AllOf(even, positive, big), 4, false},
+		{"empty all", AllOf[int](), 0, true},
+		{"any true", AnyOf(even, big), 7, true},
+		{"any false", AnyOf(even, big), 3, false},
+		{"empty any", AnyOf[int](), 0, false},
+		{"none true", NoneOf(even, big), 3, true},
+		{"none false", NoneOf(even, big), 4, false},
+		{"at least met", AtLeastN(2, even, positive, big), 4, true},
+		{"at least unmet", AtLeastN(2, even, positive, big), 3, false},
+		{"at least zero", AtLeastN(0, even), 3, true},
+		{"at least more than operands", AtLeastN(3, even, positive), 4, false},
+		{"exactly met", ExactlyN(1, even, positive, big), 3, true},
+		{"exactly exceeded", ExactlyN(1, even, positive, big), 4, false},
+		{"exactly unmet", ExactlyN(1, even, positive, big), -3, false},
+		{"exactly zero", ExactlyN(0, even, big), 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pred.Test(tt.in); got != tt.want {
+				t.Errorf("%s on %d: expected %v, got %v", tt.pred, tt.in, tt.want, got)
+			}
+			if got := tt.pred.Explain(tt.in).Result; got != tt.want {
+				t.Errorf("explanation of %s on %d: expected %v, got %v", tt.pred, tt.in, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCombinatorsShortCircuit(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(pass, fail Named[int]) Named[int]
+		calls int
+	}{
+		{"all stops at first failure", func(p, f Named[int]) Named[int] { return AllOf(p, f, p, p) }, 2},
+		{"any stops at first success", func(p, f Named[int]) Named[int] { return AnyOf(f, p, f, f) }, 2},
+		{"none stops at first success", func(p, f Named[int]) Named[int] { return NoneOf(f, p, f) }, 2},
+		{"at least stops when reached", func(p, f Named[int]) Named[int] { return AtLeastN(2, p, p, f, f) }, 2},
+		{"at least stops when unreachable", func(p, f Named[int]) Named[int] { return AtLeastN(3, f, f, p, p) }, 2},
+		{"exactly stops when exceeded", func(p, f Named[int]) Named[int] { return ExactlyN(1, p, p, f, f) }, 2},
+		{"exactly checks the rest", func(p, f Named[int]) Named[int] { return ExactlyN(1, p, f, f) }, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			pred := tt.build(tracked("pass", true, &calls), tracked("fail", false, &calls))
+			pred.Test(0)
+			if calls != tt.calls {
+				t.Errorf("Test: expected %d evaluations, got %d", tt.calls, calls)
+			}
+			calls = 0
+			pred.Explain(0)
+			if calls != tt.calls {
+				t.Errorf("Explain: expected %d evaluations, got %d", tt.calls, calls)
+			}
+		})
+	}
+}
+
+func TestExplainThreshold(t *testing.T) {
+	even := Leaf("even", Predicate[int](IsEven))
+	positive := Leaf("positive", Predicate[int](IsPositive))
+	big := Leaf("big", GreaterThan(5))
+
+	e := AtLeastN(2, even, positive, big).Explain(4)
+	want := `[passed] AT LEAST 2 OF
+  [passed] even
+  [passed] positive
+  [skipped] big`
+	if got := e.String(); got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+	if got := e.Reason(); got != "positive passed" {
+		t.Errorf("expected the second operand to decide, got %q", got)
+	}
+
+	if got := AtLeastN(0, even).Explain(1).Reason(); got != "AT LEAST 0 OF (even) passed" {
+		t.Errorf("expected the node itself to decide, got %q", got)
+	}
+}
@@ -0,0 +1,8 @@
+package predicate
+
+import "errors"
+
+// ErrMixedCombinators is reported by predicate builders, including the ones
+// generated by predicategen, for a call that would change how the conditions
+// already added are combined.
+var ErrMixedCombinators = errors.New("builder mixes combinators")
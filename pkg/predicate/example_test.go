@@ -0,0 +1,377 @@
+package predicate_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+
+func ExampleFilter() {
+	numbers := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	// Plain functions convert to Predicate directly
+	fmt.Println(predicate.Filter(numbers, predicate.IsEven))
+
+	// Predicate constructors capture their parameters
+	fmt.Println(predicate.Filter(numbers, predicate.Between(3, 7)))
+
+	// Output:
+	// [2 4 6 8 10]
+	// [3 4 5 6 7]
+}
+
+func ExampleAnd() {
+	numbers := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	evenAndGreater := predicate.And(predicate.IsEven, predicate.GreaterThan(5))
+	fmt.Println(predicate.Filter(numbers, evenAndGreater))
+
+	// Output:
+	// [6 8 10]
+}
+
+func ExampleNot() {
+	words := []string{"apple", "banana", "cherry", "blueberry"}
+
+	notB := predicate.Not(predicate.HasPrefix("b"))
+	fmt.Println(predicate.Filter(words, notB))
+
+	// Output:
+	// [apple cherry]
+}
+
+func ExampleFind() {
+	words := []string{"fig", "date", "elderberry", "grape"}
+
+	if word, ok := predicate.Find(words, predicate.LongerThan(5)); ok {
+		fmt.Println("First long word:", word)
+	}
+
+	// Output:
+	// First long word: elderberry
+}
+
+func ExampleCount() {
+	numbers := []int{-2, -1, 0, 1, 2, 3}
+
+	fmt.Println("Positive:", predicate.Count(numbers, predicate.IsPositive))
+	fmt.Println("Any odd:", predicate.Any(numbers, predicate.IsOdd))
+	fmt.Println("All odd:", predicate.All(numbers, predicate.IsOdd))
+
+	// Output:
+	// Positive: 3
+	// Any odd: true
+	// All odd: false
+}
+
+func ExampleFilterSeq() {
+	// An unbounded stream: only the values that are actually needed are generated
+	naturals := func(yield func(int) bool) {
+		for n := 1; ; n++ {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+
+	for n := range predicate.FilterSeq(naturals, predicate.IsEven) {
+		if n > 8 {
+			break
+		}
+		fmt.Println(n)
+	}
+
+	// Output:
+	// 2
+	// 4
+	// 6
+	// 8
+}
+
+func ExampleFilterSeq2() {
+	stock := map[string]int{"apple": 0, "banana": 12, "cherry": 3}
+
+	inStock := predicate.FilterSeq2(maps.All(stock), predicate.Values[string](predicate.IsPositive))
+	for _, name := range slices.Sorted(maps.Keys(maps.Collect(inStock))) {
+		fmt.Println(name)
+	}
+
+	// Output:
+	// banana
+	// cherry
+}
+
+func ExampleParallelFilter() {
+	numbers := make([]int, 100)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+
+	// Results keep the input order regardless of how the work is split
+	multiplesOf15 := func(n int) bool { return n%15 == 0 }
+	fmt.Println(predicate.ParallelFilter(numbers, multiplesOf15, predicate.WithWorkers(4), predicate.WithChunkSize(10)))
+	fmt.Println(predicate.ParallelCount(numbers, predicate.IsEven, predicate.WithWorkers(4)))
+
+	// Output:
+	// [15 30 45 60 75 90]
+	// 50
+}
+
+func ExampleCompileQuery() {
+	type User struct {
+		Name    string
+		Age     int
+		Active  bool
+		Role    string
+		Country string `query:"country"`
+	}
+
+	users := []User{
+		{Name: "Alice", Age: 25, Active: true, Role: "admin", Country: "USA"},
+		{Name: "Bob", Age: 30, Active: true, Role: "user", Country: "UK"},
+		{Name: "Charlie", Age: 35, Active: false, Role: "admin", Country: "USA"},
+	}
+
+	pred, err := predicate.CompileQuery[User]("age > 25 AND (role = 'admin' OR country IN ('USA','UK')) AND NOT active")
+	if err != nil {
+		panic(err)
+	}
+	for _, u := range predicate.Filter(users, pred) {
+		fmt.Println(u.Name)
+	}
+
+	_, err = predicate.CompileQuery[User]("age > 'old'")
+	fmt.Println(err)
+
+	// Output:
+	// Charlie
+	// type error at column 7: cannot compare int field Age with string 'old'
+}
+
+func ExampleLeaf() {
+	type Product struct {
+		Category string
+		InStock  bool
+	}
+
+	byCategory := func(category string) predicate.Named[Product] {
+		return predicate.Leaf("category", func(p Product) bool {
+			return p.Category == category
+		}, category).WithFormat("category = %q")
+	}
+	inStock := predicate.Leaf("in_stock", func(p Product) bool { return p.InStock })
+
+	pred := byCategory("Electronics").And(inStock)
+	fmt.Println(pred)
+	fmt.Println(pred.Test(Product{Category: "Electronics", InStock: true}))
+	fmt.Println(pred.Node().Children[0].Args)
+
+	// Output:
+	// (category = "Electronics" AND in_stock)
+	// true
+	// [Electronics]
+}
+
+func ExampleAllOf() {
+	even := predicate.Leaf("even", predicate.Predicate[int](predicate.IsEven))
+	positive := predicate.Leaf("positive", predicate.Predicate[int](predicate.IsPositive))
+	big := predicate.Leaf("big", predicate.GreaterThan(5)).WithFormat("n > 5")
+
+	// Nested combinators of the same kind collapse into one node
+	all := predicate.AllOf(predicate.AllOf(even, positive), big)
+	fmt.Println(all, predicate.Filter([]int{-2, 3, 4, 6, 8}, all.Predicate()))
+
+	twoOfThree := predicate.AtLeastN(2, even, positive, big)
+	fmt.Println(twoOfThree, predicate.Filter([]int{-2, 3, 4, 7}, twoOfThree.Predicate()))
+
+	fmt.Println(predicate.NoneOf(even, big))
+
+	// Output:
+	// (even AND positive AND n > 5) [6 8]
+	// AT LEAST 2 OF (even, positive, n > 5) [4 7]
+	// NOT (even OR n > 5)
+}
+
+func ExampleAdaptive() {
+	expensive := predicate.Leaf("expensive", func(n int) bool {
+		return strings.Contains(strconv.Itoa(n*n), "1")
+	})
+	rare := predicate.Leaf("rare", func(n int) bool { return n%100 == 0 })
+
+	// After enough calls, rare runs first and expensive only for multiples of 100
+	pred := predicate.Adaptive(expensive.And(rare), predicate.WithReorderEvery(256))
+	fmt.Println(pred)
+	numbers := make([]int, 10000)
+	for i := range numbers {
+		numbers[i] = i
+	}
+	fmt.Println(predicate.Count(numbers, pred.Test))
+
+	// Output:
+	// (expensive AND rare)
+	// 42
+}
+
+func ExampleSimplify() {
+	active := predicate.Leaf("active", func(u string) bool { return !strings.HasPrefix(u, "x-") })
+	admin := predicate.Leaf("admin", predicate.HasPrefix("admin"))
+	guest := predicate.Leaf("guest", predicate.HasPrefix("guest"))
+
+	messy := predicate.AllOf(active.Not().Not(), admin.Or(guest).Not(), active, guest.Or(predicate.True[string]()))
+	fmt.Println(messy)
+	fmt.Println(predicate.Simplify(messy))
+	fmt.Println(predicate.DNF(active.And(admin.Or(guest))))
+
+	// Output:
+	// (NOT NOT active AND NOT (admin OR guest) AND active AND (guest OR TRUE))
+	// (active AND NOT admin AND NOT guest)
+	// ((active AND admin) OR (active AND guest))
+}
+
+func ExampleField() {
+	type Product struct {
+		Name     string
+		Category string
+		Price    float64
+	}
+	products := []Product{
+		{"Laptop", "Electronics", 999.99},
+		{"Mouse", "Electronics", 29.99},
+		{"Desk", "Furniture", 299.99},
+		{"Monitor", "Electronics", 399.99},
+	}
+
+	price := predicate.Field(func(p Product) float64 { return p.Price })
+	category := predicate.Field(func(p Product) string { return p.Category })
+
+	for _, p := range predicate.Filter(products, predicate.And(price.Between(100, 400), category.In("Electronics", "Garden"))) {
+		fmt.Println(p.Name)
+	}
+
+	// Output:
+	// Monitor
+}
+
+func ExamplePartition() {
+	inStock, soldOut := predicate.Partition([]string{"laptop", "-mouse", "desk", "-lamp"},
+		predicate.Not(predicate.HasPrefix("-")))
+	fmt.Println(inStock, soldOut)
+
+	byLength := predicate.GroupBy([]string{"go", "rust", "c", "zig", "java"}, func(s string) int { return len(s) })
+	fmt.Println(byLength[4])
+
+	fmt.Println(predicate.Chunk([]int{1, 2, 3, 4, 5}, 2))
+
+	// Output:
+	// [laptop desk] [-mouse -lamp]
+	// [rust java]
+	// [[1 2] [3 4] [5]]
+}
+
+func ExampleMemoize() {
+	type Document struct {
+		ID   int
+		Body string
+	}
+	docs := []Document{{1, "quarterly report"}, {2, "draft"}, {3, "final report"}}
+
+	// Pretend this is an expensive regular expression or scoring model
+	mentionsReport := predicate.Memoize(func(d Document) bool {
+		return strings.Contains(d.Body, "report")
+	}, func(d Document) int { return d.ID }, predicate.WithCapacity(100))
+
+	fmt.Println(predicate.Count(docs, mentionsReport.Test))
+	fmt.Println(predicate.Any(docs, mentionsReport.Test))
+	stats := mentionsReport.Stats()
+	fmt.Println(stats.Hits, stats.Misses, stats.Size)
+
+	// Output:
+	// 2
+	// true
+	// 1 3 3
+}
+
+func ExampleFuzzyWord() {
+	names := []string{"Mechanical Keyboard", "Keyring", "Wireless Mouse", "Key Cap Set"}
+
+	fmt.Println(predicate.Filter(names, predicate.FuzzyWord("keybaord", 1)))
+	fmt.Println(predicate.Filter(names, predicate.HasWord("KEY")))
+	fmt.Println(predicate.Filter(names, predicate.HasTokenPrefix("key")))
+	fmt.Println(predicate.DamerauDistance("keybaord", "keyboard"), predicate.EditDistance("keybaord", "keyboard"))
+
+	// Output:
+	// [Mechanical Keyboard]
+	// [Key Cap Set]
+	// [Mechanical Keyboard Keyring Key Cap Set]
+	// 1 2
+}
+
+func ExampleWithinLast() {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now } // time.Now in production
+	logins := []time.Time{now.Add(-10 * time.Minute), now.Add(-3 * time.Hour), now.Add(-50 * time.Minute)}
+
+	recent := predicate.WithinLast(time.Hour, predicate.WithClock(clock))
+	fmt.Println(predicate.Count(logins, recent))
+
+	tokyo := time.FixedZone("JST", 9*60*60)
+	fmt.Println(predicate.InBusinessHours(tokyo, 9*time.Hour, 18*time.Hour)(now)) // 21:00 in Tokyo
+
+	// Output:
+	// 2
+	// false
+}
+
+func ExampleSliceField() {
+	type Article struct {
+		Title string
+		Tags  []string
+	}
+	articles := []Article{
+		{"Generics in practice", []string{"go", "generics"}},
+		{"Borrow checker tips", []string{"rust"}},
+		{"Iterators", []string{"go", "iter", "generics"}},
+	}
+	tags := predicate.SliceField(func(a Article) []string { return a.Tags })
+
+	for _, a := range predicate.Filter(articles, predicate.And(tags.ContainsAll("go", "generics"), tags.SizeBetween(0, 2))) {
+		fmt.Println(a.Title)
+	}
+	fmt.Println(predicate.Count(articles, tags.ContainsNone("go")))
+
+	// Output:
+	// Generics in practice
+	// 1
+}
+
+func ExampleFilterCtx() {
+	permissions := map[string]bool{"alice": true, "bob": false}
+
+	// A check that consults a (pretend) remote permission service
+	canRead := func(ctx context.Context, user string) (bool, error) {
+		allowed, ok := permissions[user]
+		if !ok {
+			return false, fmt.Errorf("unknown user %q", user)
+		}
+		return allowed, nil
+	}
+	// Pure predicates mix in through Lift and LiftCtx
+	notBlocked := predicate.LiftCtx(predicate.Lift(predicate.Not(predicate.HasPrefix("blocked-"))))
+
+	ctx := context.Background()
+	readers, err := predicate.FilterCtx(ctx, []string{"alice", "bob"}, predicate.AndCtx(notBlocked, canRead))
+	fmt.Println(readers, err)
+
+	_, err = predicate.FilterCtx(ctx, []string{"alice", "mallory"}, predicate.AndCtx(notBlocked, canRead))
+	fmt.Println(err)
+
+	// Output:
+	// [alice] <nil>
+	// predicate: item 1: unknown user "mallory"
+}
@@ -0,0 +1,176 @@
+package predicate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Explanation records how a composed predicate evaluated a single item.
+// Each node mirrors a node of the predicate tree together with its result.
+// Children that were never evaluated because of short-circuiting are kept
+// and marked as Skipped.
+type Explanation struct {
+	Op       Op
+	Expr     string // rendering of the node, e.g. price <= 300
+	Result   bool
+	Skipped  bool
+	Detail   string // value inspected by a leaf, e.g. Price=399.99
+	Count    int    // threshold of AT LEAST and EXACTLY nodes
+	Children []Explanation
+}
+
+// ExplainLeaf creates the explanation of an evaluated leaf.
+func ExplainLeaf(expr string, result bool, detail string) Explanation {
+	return Explanation{Op: OpLeaf, Expr: expr, Result: result, Detail: detail}
+}
+
+// ExplainNot creates the explanation of a negation from its operand.
+func ExplainNot(operand Explanation) Explanation {
+	return Explanation{
+		Op:       OpNot,
+		Expr:     "NOT " + operand.Expr,
+		Result:   !operand.Result,
+		Children: []Explanation{operand},
+	}
+}
+
+// ExplainAll creates the explanation of an AND or OR node with n operands.
+// Operands are explained in order by calling explain(i), stopping as soon as
+// the result is decided; the remaining operands are rendered by describe(i)
+// without being evaluated and are marked as Skipped.
+func ExplainAll(op Op, n int, explain, describe func(i int) Explanation) Explanation {
+	return ExplainCount(op, 0, n, explain, describe)
+}
+
+// ExplainCount is like ExplainAll but also accepts AT LEAST and EXACTLY nodes
+// with the given threshold count.
+func ExplainCount(op Op, count, n int, explain, describe func(i int) Explanation) Explanation {
+	e := Explanation{Op: op, Count: count}
+	parts := make([]string, n)
+	passed, evaluated := 0, 0
+	result, decided := decide(op, count, 0, 0, n)
+	for i := range n {
+		var child Explanation
+		if decided {
+			child = describe(i)
+			child.Skipped = true
+		} else {
+			child = explain(i)
+			evaluated++
+			if child.Result {
+				passed++
+			}
+			result, decided = decide(op, count, passed, evaluated, n)
+		}
+		parts[i] = child.Expr
+		e.Children = append(e.Children, child)
+	}
+	e.Result = result
+	e.Expr = joinExpr(op, count, parts)
+	return e
+}
+
+// Deciding returns the leaf that determined the overall result: the first
+// failing operand of a failed AND, the first passing operand of a passed OR,
+// and otherwise the last evaluated operand with the same result as the node.
+// A node without such an operand, e.g. an EXACTLY node that failed because
+// too many operands passed, is returned itself.
+func (e Explanation) Deciding() Explanation {
+	switch e.Op {
+	case OpLeaf:
+		return e
+	case OpNot:
+		return e.Children[0].Deciding()
+	}
+	deciding := -1
+	for i, child := range e.Children {
+		if child.Skipped {
+			break
+		}
+		if (e.Op == OpAnd || e.Op == OpOr) && child.Result == (e.Op == OpOr) {
+			return child.Deciding()
+		}
+		if child.Result == e.Result {
+			deciding = i
+		}
+	}
+	if deciding < 0 {
+		return e
+	}
+	return e.Children[deciding].Deciding()
+}
+
+// Reason describes the deciding leaf, e.g. "price <= 300 failed: Price=399.99".
+func (e Explanation) Reason() string {
+	d := e.Deciding()
+	reason := d.Expr + " " + outcome(d.Result)
+	if d.Detail != "" {
+		reason += ": " + d.Detail
+	}
+	return reason
+}
+
+// String renders the explanation as an indented tree, one node per line.
+func (e Explanation) String() string {
+	var sb strings.Builder
+	e.write(&sb, 0)
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func (e Explanation) write(sb *strings.Builder, depth int) {
+	status := outcome(e.Result)
+	if e.Skipped {
+		status = "skipped"
+	}
+	expr := e.Expr
+	if e.Op != OpLeaf {
+		expr = label(e.Op, e.Count)
+	}
+	fmt.Fprintf(sb, "%s[%s] %s", strings.Repeat("  ", depth), status, expr)
+	if e.Detail != "" && !e.Skipped {
+		fmt.Fprintf(sb, " (%s)", e.Detail)
+	}
+	sb.WriteString("\n")
+	for _, child := range e.Children {
+		child.write(sb, depth+1)
+	}
+}
+
+func outcome(result bool) string {
+	if result {
+		return "passed"
+	}
+	return "failed"
+}
+
+// Explain evaluates the predicate against item and reports the result of
+// every node, following the same short-circuit order as Test.
+func (n Named[T]) Explain(item T) Explanation {
+	return n.explain(item)
+}
+
+func (n Named[T]) explain(item T) Explanation {
+	switch n.node.Op {
+	case OpNot:
+		return ExplainNot(n.operands[0].explain(item))
+	case OpAnd, OpOr, OpAtLeast, OpExactly:
+		return ExplainCount(n.node.Op, n.node.Count, len(n.operands),
+			func(i int) Explanation { return n.operands[i].explain(item) },
+			func(i int) Explanation { return n.operands[i].unevaluated() },
+		)
+	}
+	var detail string
+	if n.detail != nil {
+		detail = n.detail(item)
+	}
+	return ExplainLeaf(n.node.String(), n.test(item), detail)
+}
+
+// unevaluated describes the predicate tree without evaluating it.
+func (n Named[T]) unevaluated() Explanation {
+	e := Explanation{Op: n.node.Op, Expr: n.node.String(), Count: n.node.Count, Skipped: true}
+	for _, operand := range n.operands {
+		e.Children = append(e.Children, operand.unevaluated())
+	}
+	return e
+}
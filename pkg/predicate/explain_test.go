@@ -0,0 +1,121 @@
+package predicate
+
+import (
+	"fmt"
+	"testing"
+)
+
+type explainItem struct {
+	Price   float64
+	InStock bool
+}
+
+func maxPrice(limit float64) Named[explainItem] {
+	return Leaf("max_price", func(i explainItem) bool { return i.Price <= limit }, limit).
+		WithFormat("price <= %v").
+		WithDetail(func(i explainItem) string { return fmt.Sprintf("Price=%.2f", i.Price) })
+}
+
+func inStock() Named[explainItem] {
+	return Leaf("in_stock", func(i explainItem) bool { return i.InStock })
+}
+
+func TestExplainReason(t *testing.T) {
+	pred := inStock().And(maxPrice(300))
+
+	tests := []struct {
+		name string
+		pred Named[explainItem]
+		item explainItem
+		want string
+	}{
+		{"and fails on second", pred, explainItem{Price: 399.99, InStock: true}, "price <= 300 failed: Price=399.99"},
+		{"and fails on first", pred, explainItem{Price: 10}, "in_stock failed"},
+		{"and passes", pred, explainItem{Price: 10, InStock: true}, "price <= 300 passed: Price=10.00"},
+		{"or passes on second", inStock().Or(maxPrice(300)), explainItem{Price: 10}, "price <= 300 passed: Price=10.00"},
+		{"not", inStock().Not(), explainItem{InStock: true}, "in_stock passed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.pred.Explain(tt.item)
+			if e.Result != tt.pred.Test(tt.item) {
+				t.Errorf("explanation result %v differs from Test", e.Result)
+			}
+			if got := e.Reason(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExplainThresholdReason(t *testing.T) {
+	cheap := maxPrice(300)
+	tests := []struct {
+		name string
+		pred Named[explainItem]
+		item explainItem
+		want string
+	}{
+		{"exactly passes", ExactlyN(1, inStock(), cheap), explainItem{Price: 500, InStock: true}, "in_stock passed"},
+		{"exactly fails on too many", ExactlyN(1, inStock(), cheap), explainItem{Price: 10, InStock: true}, "EXACTLY 1 OF (in_stock, price <= 300) failed"},
+		{"exactly fails on too few", ExactlyN(1, inStock(), cheap), explainItem{Price: 500}, "price <= 300 failed: Price=500.00"},
+		{"at least fails", AtLeastN(2, inStock(), cheap, inStock()), explainItem{Price: 10}, "in_stock failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := tt.pred.Explain(tt.item)
+			if e.Result != tt.pred.Test(tt.item) {
+				t.Errorf("explanation result %v differs from Test", e.Result)
+			}
+			if got := e.Reason(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if d := e.Deciding(); d.Result != e.Result {
+				t.Errorf("expected the deciding node to share the result %v, got %+v", e.Result, d)
+			}
+		})
+	}
+}
+
+func TestExplainShortCircuit(t *testing.T) {
+	calls := 0
+	counted := Leaf("counted", func(explainItem) bool {
+		calls++
+		return true
+	})
+
+	e := inStock().And(counted.Or(maxPrice(1))).Explain(explainItem{Price: 5})
+	if calls != 0 {
+		t.Errorf("expected skipped operands not to be evaluated, got %d calls", calls)
+	}
+	if e.Result {
+		t.Error("expected the explanation to fail")
+	}
+	skipped := e.Children[1]
+	if !skipped.Skipped || !skipped.Children[0].Skipped || skipped.Expr != "(counted OR price <= 1)" {
+		t.Errorf("expected a skipped OR subtree, got %+v", skipped)
+	}
+}
+
+func TestExplainString(t *testing.T) {
+	pred := maxPrice(300).And(inStock().Not())
+	got := pred.Explain(explainItem{Price: 100, InStock: true}).String()
+	want := `[failed] AND
+  [passed] price <= 300 (Price=100.00)
+  [failed] NOT
+    [passed] in_stock`
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	got = pred.Explain(explainItem{Price: 500}).String()
+	want = `[failed] AND
+  [failed] price <= 300 (Price=500.00)
+  [skipped] NOT
+    [skipped] in_stock`
+	if got != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, got)
+	}
+}
@@ -0,0 +1,171 @@
+package predicate
+
+import (
+	"context"
+	"fmt"
+)
+
+// PredicateE is a predicate that can fail, e.g. because it consults a cache
+// or a remote service.
+type PredicateE[T any] func(T) (bool, error)
+
+// PredicateCtx is a fallible predicate that honours cancellation and
+// deadlines carried by a context.
+type PredicateCtx[T any] func(context.Context, T) (bool, error)
+
+// Lift adapts a pure predicate to a PredicateE that never fails.
+func Lift[T any](p Predicate[T]) PredicateE[T] {
+	return func(item T) (bool, error) {
+		return p(item), nil
+	}
+}
+
+// LiftCtx adapts a fallible predicate to a PredicateCtx that ignores the
+// context. Use Lift first to adapt a pure predicate.
+func LiftCtx[T any](p PredicateE[T]) PredicateCtx[T] {
+	return func(_ context.Context, item T) (bool, error) {
+		return p(item)
+	}
+}
+
+// FilterE is like Filter but stops at the first error, returning it wrapped
+// with the index of the failing item.
+func FilterE[T any](items []T, predicate PredicateE[T]) ([]T, error) {
+	var result []T
+	for i, item := range items {
+		ok, err := predicate(item)
+		if err != nil {
+			return nil, itemError(i, err)
+		}
+		if ok {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// FindE is like Find but stops at the first error.
+func FindE[T any](items []T, predicate PredicateE[T]) (T, bool, error) {
+	var zero T
+	for i, item := range items {
+		ok, err := predicate(item)
+		if err != nil {
+			return zero, false, itemError(i, err)
+		}
+		if ok {
+			return item, true, nil
+		}
+	}
+	return zero, false, nil
+}
+
+// CountE is like Count but stops at the first error.
+func CountE[T any](items []T, predicate PredicateE[T]) (int, error) {
+	count := 0
+	for i, item := range items {
+		ok, err := predicate(item)
+		if err != nil {
+			return 0, itemError(i, err)
+		}
+		if ok {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FilterCtx is like FilterE but also stops with ctx.Err() as soon as the
+// context is cancelled or its deadline passes.
+func FilterCtx[T any](ctx context.Context, items []T, predicate PredicateCtx[T]) ([]T, error) {
+	return FilterE(items, bind(ctx, predicate))
+}
+
+// FindCtx is like FindE but also stops when the context is done.
+func FindCtx[T any](ctx context.Context, items []T, predicate PredicateCtx[T]) (T, bool, error) {
+	return FindE(items, bind(ctx, predicate))
+}
+
+// CountCtx is like CountE but also stops when the context is done.
+func CountCtx[T any](ctx context.Context, items []T, predicate PredicateCtx[T]) (int, error) {
+	return CountE(items, bind(ctx, predicate))
+}
+
+// bind checks the context before every evaluation.
+func bind[T any](ctx context.Context, predicate PredicateCtx[T]) PredicateE[T] {
+	return func(item T) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		return predicate(ctx, item)
+	}
+}
+
+func itemError(i int, err error) error {
+	return fmt.Errorf("predicate: item %d: %w", i, err)
+}
+
+// Fallible predicate combinators
+
+// AndE combines two fallible predicates with logical AND. The second
+// predicate is not evaluated if the first fails or returns false.
+func AndE[T any](p1, p2 PredicateE[T]) PredicateE[T] {
+	return func(item T) (bool, error) {
+		if ok, err := p1(item); err != nil || !ok {
+			return false, err
+		}
+		return p2(item)
+	}
+}
+
+// OrE combines two fallible predicates with logical OR. The second
+// predicate is not evaluated if the first fails or returns true.
+func OrE[T any](p1, p2 PredicateE[T]) PredicateE[T] {
+	return func(item T) (bool, error) {
+		if ok, err := p1(item); err != nil || ok {
+			return ok, err
+		}
+		return p2(item)
+	}
+}
+
+// NotE negates a fallible predicate. Errors are passed through unchanged.
+func NotE[T any](p PredicateE[T]) PredicateE[T] {
+	return func(item T) (bool, error) {
+		ok, err := p(item)
+		if err != nil {
+			return false, err
+		}
+		return !ok, nil
+	}
+}
+
+// AndCtx combines two context-aware predicates with logical AND.
+func AndCtx[T any](p1, p2 PredicateCtx[T]) PredicateCtx[T] {
+	return func(ctx context.Context, item T) (bool, error) {
+		if ok, err := p1(ctx, item); err != nil || !ok {
+			return false, err
+		}
+		return p2(ctx, item)
+	}
+}
+
+// OrCtx combines two context-aware predicates with logical OR.
+func OrCtx[T any](p1, p2 PredicateCtx[T]) PredicateCtx[T] {
+	return func(ctx context.Context, item T) (bool, error) {
+		if ok, err := p1(ctx, item); err != nil || ok {
+			return ok, err
+		}
+		return p2(ctx, item)
+	}
+}
+
+// NotCtx negates a context-aware predicate.
+func NotCtx[T any](p PredicateCtx[T]) PredicateCtx[T] {
+	return func(ctx context.Context, item T) (bool, error) {
+		ok, err := p(ctx, item)
+		if err != nil {
+			return false, err
+		}
+		return !ok, nil
+	}
+}
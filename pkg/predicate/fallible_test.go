@@ -0,0 +1,144 @@
+package predicate
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+var errLookup = errors.New("lookup failed")
+
+// failOn returns a fallible predicate that reports even numbers and fails on bad.
+func failOn(bad int) PredicateE[int] {
+	return func(n int) (bool, error) {
+		if n == bad {
+			return false, errLookup
+		}
+		return IsEven(n), nil
+	}
+}
+
+func TestFilterE(t *testing.T) {
+	got, err := FilterE([]int{1, 2, 3, 4}, failOn(-1))
+	if err != nil || !slices.Equal(got, []int{2, 4}) {
+		t.Errorf("expected [2 4], got %v (%v)", got, err)
+	}
+
+	got, err = FilterE([]int{1, 2, 3, 4}, failOn(3))
+	if !errors.Is(err, errLookup) || got != nil {
+		t.Errorf("expected errLookup and no result, got %v (%v)", got, err)
+	}
+	if err.Error() != "predicate: item 2: lookup failed" {
+		t.Errorf("unexpected error message %q", err)
+	}
+}
+
+func TestFindECountE(t *testing.T) {
+	got, ok, err := FindE([]int{1, 3, 4, 5}, failOn(5))
+	if err != nil || !ok || got != 4 {
+		t.Errorf("expected (4, true, nil), got (%d, %v, %v)", got, ok, err)
+	}
+	if _, _, err := FindE([]int{1, 5, 4}, failOn(5)); !errors.Is(err, errLookup) {
+		t.Errorf("expected errLookup, got %v", err)
+	}
+
+	n, err := CountE([]int{1, 2, 4}, failOn(-1))
+	if err != nil || n != 2 {
+		t.Errorf("expected 2, got %d (%v)", n, err)
+	}
+	if _, err := CountE([]int{1, 2, 4}, failOn(4)); !errors.Is(err, errLookup) {
+		t.Errorf("expected errLookup, got %v", err)
+	}
+}
+
+func TestFilterCtxStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	seen := 0
+	pred := func(_ context.Context, n int) (bool, error) {
+		seen++
+		if n == 3 {
+			cancel()
+		}
+		return true, nil
+	}
+
+	_, err := FilterCtx(ctx, []int{1, 2, 3, 4, 5}, pred)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if seen != 3 {
+		t.Errorf("expected evaluation to stop after 3 items, got %d", seen)
+	}
+}
+
+func TestCtxFunctions(t *testing.T) {
+	ctx := context.Background()
+	even := LiftCtx(Lift[int](IsEven))
+
+	got, err := FilterCtx(ctx, []int{1, 2, 3, 4}, even)
+	if err != nil || !slices.Equal(got, []int{2, 4}) {
+		t.Errorf("expected [2 4], got %v (%v)", got, err)
+	}
+	found, ok, err := FindCtx(ctx, []int{1, 3, 6}, even)
+	if err != nil || !ok || found != 6 {
+		t.Errorf("expected (6, true, nil), got (%d, %v, %v)", found, ok, err)
+	}
+	n, err := CountCtx(ctx, []int{1, 2, 3, 4}, even)
+	if err != nil || n != 2 {
+		t.Errorf("expected 2, got %d (%v)", n, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := CountCtx(cancelled, []int{1}, even); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFallibleCombinators(t *testing.T) {
+	positive := Lift[int](IsPositive)
+	broken := PredicateE[int](func(int) (bool, error) { return false, errLookup })
+
+	tests := []struct {
+		name    string
+		pred    PredicateE[int]
+		in      int
+		want    bool
+		wantErr bool
+	}{
+		{"and short-circuits before error", AndE(positive, broken), -1, false, false},
+		{"and reaches error", AndE(positive, broken), 1, false, true},
+		{"and error first", AndE(broken, positive), 1, false, true},
+		{"and pure", AndE(positive, Lift[int](IsEven)), 2, true, false},
+		{"or short-circuits before error", OrE(positive, broken), 1, true, false},
+		{"or reaches error", OrE(positive, broken), -1, false, true},
+		{"not", NotE(positive), -1, true, false},
+		{"not error", NotE(broken), 1, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.pred(tt.in)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("expected (%v, err=%v), got (%v, %v)", tt.want, tt.wantErr, got, err)
+			}
+		})
+	}
+}
+
+func TestCtxCombinators(t *testing.T) {
+	ctx := context.Background()
+	positive := LiftCtx(Lift[int](IsPositive))
+	even := LiftCtx(Lift[int](IsEven))
+
+	if ok, _ := AndCtx(positive, even)(ctx, 2); !ok {
+		t.Error("expected AndCtx to match 2")
+	}
+	if ok, _ := OrCtx(positive, even)(ctx, -3); ok {
+		t.Error("expected OrCtx not to match -3")
+	}
+	if ok, _ := NotCtx(even)(ctx, 3); !ok {
+		t.Error("expected NotCtx to match 3")
+	}
+}
@@ -0,0 +1,112 @@
+package predicate
+
+import "cmp"
+
+// Accessor reads a field of type V from a value of type T. Its methods build
+// predicates on that field, so one accessor replaces a handwritten
+// constructor per field and comparison:
+//
+//	price := predicate.Field(func(p Product) float64 { return p.Price })
+//	affordable := price.Between(100, 400)
+type Accessor[T any, V cmp.Ordered] func(T) V
+
+// Field creates an Accessor from a getter.
+func Field[T any, V cmp.Ordered](get func(T) V) Accessor[T, V] {
+	return get
+}
+
+// Eq matches values whose field equals v.
+func (a Accessor[T, V]) Eq(v V) Predicate[T] {
+	return func(item T) bool {
+		return a(item) == v
+	}
+}
+
+// Ne matches values whose field differs from v.
+func (a Accessor[T, V]) Ne(v V) Predicate[T] {
+	return func(item T) bool {
+		return a(item) != v
+	}
+}
+
+// Gt matches values whose field is greater than v.
+func (a Accessor[T, V]) Gt(v V) Predicate[T] {
+	return func(item T) bool {
+		return a(item) > v
+	}
+}
+
+// Ge matches values whose field is greater than or equal to v.
+func (a Accessor[T, V]) Ge(v V) Predicate[T] {
+	return func(item T) bool {
+		return a(item) >= v
+	}
+}
+
+// Lt matches values whose field is less than v.
+func (a Accessor[T, V]) Lt(v V) Predicate[T] {
+	return func(item T) bool {
+		return a(item) < v
+	}
+}
+
+// Le matches values whose field is less than or equal to v.
+func (a Accessor[T, V]) Le(v V) Predicate[T] {
+	return func(item T) bool {
+		return a(item) <= v
+	}
+}
+
+// Between matches values whose field lies in the inclusive range [min, max].
+func (a Accessor[T, V]) Between(min, max V) Predicate[T] {
+	return func(item T) bool {
+		v := a(item)
+		return v >= min && v <= max
+	}
+}
+
+// In matches values whose field equals one of values. With no values it
+// matches nothing.
+func (a Accessor[T, V]) In(values ...V) Predicate[T] {
+	set := toSet(values)
+	return func(item T) bool {
+		_, ok := set[a(item)]
+		return ok
+	}
+}
+
+// NotIn matches values whose field equals none of values. With no values it
+// matches everything.
+func (a Accessor[T, V]) NotIn(values ...V) Predicate[T] {
+	set := toSet(values)
+	return func(item T) bool {
+		_, ok := set[a(item)]
+		return !ok
+	}
+}
+
+// Matches applies p to the field, so any value predicate works as a field
+// predicate, e.g. name.Matches(predicate.ContainsFold("key")).
+func (a Accessor[T, V]) Matches(p Predicate[V]) Predicate[T] {
+	return func(item T) bool {
+		return p(a(item))
+	}
+}
+
+// On applies p to the value returned by get. It is the counterpart of
+// Accessor.Matches for fields that are not ordered, such as time.Time:
+//
+//	recent := predicate.On(func(u User) time.Time { return u.LastSeen }, predicate.WithinLast(time.Hour))
+func On[T, V any](get func(T) V, p Predicate[V]) Predicate[T] {
+	return func(item T) bool {
+		return p(get(item))
+	}
+}
+
+func toSet[V comparable](values []V) map[V]struct{} {
+	set := make(map[V]struct{}, len(values))
+	for _, v := range values {
+		set[v] = struct{}{}
+	}
+	return set
+}
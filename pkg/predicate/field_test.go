@@ -0,0 +1,56 @@
+package predicate
+
+import (
+	"slices"
+	"testing"
+)
+
+type fieldItem struct {
+	Name  string
+	Price float64
+	Stock int
+}
+
+func TestField(t *testing.T) {
+	items := []fieldItem{
+		{"apple", 1.5, 10},
+		{"banana", 0.5, 0},
+		{"cherry", 4, 25},
+		{"date", 4, 3},
+	}
+	name := Field(func(i fieldItem) string { return i.Name })
+	price := Field(func(i fieldItem) float64 { return i.Price })
+	stock := Field(func(i fieldItem) int { return i.Stock })
+
+	tests := []struct {
+		name string
+		pred Predicate[fieldItem]
+		want []string
+	}{
+		{"eq", price.Eq(4), []string{"cherry", "date"}},
+		{"ne", price.Ne(4), []string{"apple", "banana"}},
+		{"gt", stock.Gt(10), []string{"cherry"}},
+		{"ge", stock.Ge(10), []string{"apple", "cherry"}},
+		{"lt", price.Lt(1.5), []string{"banana"}},
+		{"le", price.Le(1.5), []string{"apple", "banana"}},
+		{"between", price.Between(1, 4), []string{"apple", "cherry", "date"}},
+		{"between strings", name.Between("b", "c"), []string{"banana"}},
+		{"in", name.In("date", "apple", "fig"), []string{"apple", "date"}},
+		{"in nothing", name.In(), nil},
+		{"not in", name.NotIn("date", "apple"), []string{"banana", "cherry"}},
+		{"matches", name.Matches(HasTokenPrefix("CH")), []string{"cherry"}},
+		{"not in nothing", stock.NotIn(), []string{"apple", "banana", "cherry", "date"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, item := range Filter(items, tt.pred) {
+				got = append(got, item.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
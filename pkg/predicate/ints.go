@@ -0,0 +1,40 @@
+package predicate
+
+import "cmp"
+
+// IsEven reports whether n is even.
+func IsEven(n int) bool {
+	return n%2 == 0
+}
+
+// IsOdd reports whether n is odd.
+func IsOdd(n int) bool {
+	return n%2 != 0
+}
+
+// IsPositive reports whether n is greater than zero.
+func IsPositive(n int) bool {
+	return n > 0
+}
+
+// GreaterThan creates a predicate that matches values above threshold.
+// It works for any ordered type, e.g. GreaterThan(5) or GreaterThan("m").
+func GreaterThan[V cmp.Ordered](threshold V) Predicate[V] {
+	return func(v V) bool {
+		return v > threshold
+	}
+}
+
+// LessThan creates a predicate that matches values below threshold.
+func LessThan[V cmp.Ordered](threshold V) Predicate[V] {
+	return func(v V) bool {
+		return v < threshold
+	}
+}
+
+// Between creates a predicate that matches values in the inclusive range [min, max].
+func Between[V cmp.Ordered](min, max V) Predicate[V] {
+	return func(v V) bool {
+		return v >= min && v <= max
+	}
+}
@@ -0,0 +1,47 @@
+package predicate
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIntPredicates(t *testing.T) {
+	tests := []struct {
+		name string
+		pred Predicate[int]
+		in   int
+		want bool
+	}{
+		{"IsEven even", IsEven, 4, true},
+		{"IsEven odd", IsEven, 3, false},
+		{"IsOdd odd", IsOdd, 3, true},
+		{"IsPositive zero", IsPositive, 0, false},
+		{"GreaterThan above", GreaterThan(5), 6, true},
+		{"GreaterThan equal", GreaterThan(5), 5, false},
+		{"LessThan below", LessThan(5), 4, true},
+		{"LessThan equal", LessThan(5), 5, false},
+		{"Between lower bound", Between(3, 7), 3, true},
+		{"Between upper bound", Between(3, 7), 7, true},
+		{"Between outside", Between(3, 7), 8, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pred(tt.in); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOrderedHelpers(t *testing.T) {
+	if !GreaterThan(1.5)(2.0) || GreaterThan("m")("a") {
+		t.Error("GreaterThan should work on floats and strings")
+	}
+	if !LessThan("m")("a") {
+		t.Error("LessThan should work on strings")
+	}
+	if got := Filter([]float64{0.5, 1, 2.5, 3}, Between(1.0, 2.5)); !slices.Equal(got, []float64{1, 2.5}) {
+		t.Errorf("expected [1 2.5], got %v", got)
+	}
+}
@@ -0,0 +1,247 @@
+package predicate
+
+import "iter"
+
+// Predicate2 is a generic function that tests a condition on a key-value pair.
+type Predicate2[K, V any] func(K, V) bool
+
+// FilterSeq returns a sequence that lazily yields the elements of seq
+// that satisfy the predicate. Nothing is evaluated until the result is ranged over.
+func FilterSeq[T any](seq iter.Seq[T], predicate Predicate[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if predicate(item) && !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// FindSeq returns the first element of seq that satisfies the predicate.
+// It stops pulling from seq as soon as a match is found.
+func FindSeq[T any](seq iter.Seq[T], predicate Predicate[T]) (T, bool) {
+	for item := range seq {
+		if predicate(item) {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// CountSeq returns the number of elements of seq that satisfy the predicate.
+func CountSeq[T any](seq iter.Seq[T], predicate Predicate[T]) int {
+	count := 0
+	for item := range seq {
+		if predicate(item) {
+			count++
+		}
+	}
+	return count
+}
+
+// AnySeq returns true if at least one element of seq satisfies the predicate.
+func AnySeq[T any](seq iter.Seq[T], predicate Predicate[T]) bool {
+	_, found := FindSeq(seq, predicate)
+	return found
+}
+
+// AllSeq returns true if all elements of seq satisfy the predicate.
+func AllSeq[T any](seq iter.Seq[T], predicate Predicate[T]) bool {
+	for item := range seq {
+		if !predicate(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterSeq2 returns a sequence that lazily yields the pairs of seq
+// that satisfy the predicate.
+func FilterSeq2[K, V any](seq iter.Seq2[K, V], predicate Predicate2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if predicate(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// FindSeq2 returns the first pair of seq that satisfies the predicate.
+func FindSeq2[K, V any](seq iter.Seq2[K, V], predicate Predicate2[K, V]) (K, V, bool) {
+	for k, v := range seq {
+		if predicate(k, v) {
+			return k, v, true
+		}
+	}
+	var zeroK K
+	var zeroV V
+	return zeroK, zeroV, false
+}
+
+// CountSeq2 returns the number of pairs of seq that satisfy the predicate.
+func CountSeq2[K, V any](seq iter.Seq2[K, V], predicate Predicate2[K, V]) int {
+	count := 0
+	for k, v := range seq {
+		if predicate(k, v) {
+			count++
+		}
+	}
+	return count
+}
+
+// AnySeq2 returns true if at least one pair of seq satisfies the predicate.
+func AnySeq2[K, V any](seq iter.Seq2[K, V], predicate Predicate2[K, V]) bool {
+	_, _, found := FindSeq2(seq, predicate)
+	return found
+}
+
+// AllSeq2 returns true if all pairs of seq satisfy the predicate.
+func AllSeq2[K, V any](seq iter.Seq2[K, V], predicate Predicate2[K, V]) bool {
+	for k, v := range seq {
+		if !predicate(k, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Keys adapts a key predicate to a Predicate2 that ignores the value.
+func Keys[K, V any](predicate Predicate[K]) Predicate2[K, V] {
+	return func(k K, _ V) bool {
+		return predicate(k)
+	}
+}
+
+// Values adapts a value predicate to a Predicate2 that ignores the key.
+func Values[K, V any](predicate Predicate[V]) Predicate2[K, V] {
+	return func(_ K, v V) bool {
+		return predicate(v)
+	}
+}
+
+// Collection operations over sequences
+
+// PartitionSeq splits the elements of seq into those that satisfy the
+// predicate and those that do not. It consumes the whole sequence.
+func PartitionSeq[T any](seq iter.Seq[T], predicate Predicate[T]) (matched, unmatched []T) {
+	for item := range seq {
+		if predicate(item) {
+			matched = append(matched, item)
+		} else {
+			unmatched = append(unmatched, item)
+		}
+	}
+	return matched, unmatched
+}
+
+// GroupBySeq groups the elements of seq by the key returned for each of
+// them. It consumes the whole sequence.
+func GroupBySeq[T any, K comparable](seq iter.Seq[T], key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for item := range seq {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
+
+// TakeWhileSeq returns a sequence that lazily yields the elements of seq
+// while they satisfy the predicate. It stops pulling from seq at the first
+// element that does not.
+func TakeWhileSeq[T any](seq iter.Seq[T], predicate Predicate[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range seq {
+			if !predicate(item) || !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhileSeq returns a sequence that lazily skips the elements of seq
+// while they satisfy the predicate and yields everything after.
+func DropWhileSeq[T any](seq iter.Seq[T], predicate Predicate[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		dropping := true
+		for item := range seq {
+			if dropping && predicate(item) {
+				continue
+			}
+			dropping = false
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// FindLastSeq returns the last element of seq that satisfies the predicate.
+// It consumes the whole sequence.
+func FindLastSeq[T any](seq iter.Seq[T], predicate Predicate[T]) (T, bool) {
+	var last T
+	found := false
+	for item := range seq {
+		if predicate(item) {
+			last, found = item, true
+		}
+	}
+	return last, found
+}
+
+// IndexOfSeq returns the position of the first element of seq that satisfies
+// the predicate, or -1 if there is none. It stops pulling from seq at the
+// first match.
+func IndexOfSeq[T any](seq iter.Seq[T], predicate Predicate[T]) int {
+	i := 0
+	for item := range seq {
+		if predicate(item) {
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// DistinctBySeq returns a sequence that lazily yields the elements of seq
+// whose key has not been seen before.
+func DistinctBySeq[T any, K comparable](seq iter.Seq[T], key func(T) K) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for item := range seq {
+			k := key(item)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// ChunkSeq returns a sequence that lazily yields consecutive slices of size
+// elements from seq; the last one may be shorter. Each chunk is a new slice.
+// ChunkSeq panics if size is less than 1.
+func ChunkSeq[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("predicate: chunk size must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for item := range seq {
+			chunk = append(chunk, item)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
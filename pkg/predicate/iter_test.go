@@ -0,0 +1,204 @@
+package predicate
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// naturals yields 1, 2, 3, ... and records how many values were pulled.
+func naturals(pulled *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for n := 1; ; n++ {
+			*pulled = n
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+func TestFilterSeq(t *testing.T) {
+	got := slices.Collect(FilterSeq(slices.Values([]int{1, 2, 3, 4, 5, 6}), IsEven))
+	want := []int{2, 4, 6}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFilterSeqIsLazy(t *testing.T) {
+	var pulled int
+	var got []int
+	for n := range FilterSeq(naturals(&pulled), IsEven) {
+		got = append(got, n)
+		if len(got) == 3 {
+			break
+		}
+	}
+
+	if !slices.Equal(got, []int{2, 4, 6}) {
+		t.Errorf("expected [2 4 6], got %v", got)
+	}
+	if pulled != 6 {
+		t.Errorf("expected 6 values pulled from the source, got %d", pulled)
+	}
+}
+
+func TestFindSeqStopsEarly(t *testing.T) {
+	var pulled int
+	got, ok := FindSeq(naturals(&pulled), GreaterThan(41))
+	if !ok || got != 42 {
+		t.Errorf("expected (42, true), got (%d, %v)", got, ok)
+	}
+	if pulled != 42 {
+		t.Errorf("expected 42 values pulled, got %d", pulled)
+	}
+
+	_, ok = FindSeq(slices.Values([]int{1, 3}), IsEven)
+	if ok {
+		t.Error("expected no match")
+	}
+}
+
+func TestCountAnyAllSeq(t *testing.T) {
+	seq := slices.Values([]int{1, 2, 3, 4, 5})
+
+	if got := CountSeq(seq, IsOdd); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+	if !AnySeq(seq, GreaterThan(4)) {
+		t.Error("expected AnySeq to find 5")
+	}
+	if AnySeq(seq, GreaterThan(5)) {
+		t.Error("expected AnySeq to find nothing above 5")
+	}
+	if !AllSeq(seq, IsPositive) {
+		t.Error("expected AllSeq positive")
+	}
+	if AllSeq(seq, IsOdd) {
+		t.Error("expected AllSeq odd to fail")
+	}
+}
+
+func TestSeq2(t *testing.T) {
+	stock := map[string]int{"apple": 0, "banana": 12, "cherry": 3, "date": 0}
+	inStock := Values[string](IsPositive)
+
+	got := maps.Collect(FilterSeq2(maps.All(stock), inStock))
+	want := map[string]int{"banana": 12, "cherry": 3}
+	if !maps.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if n := CountSeq2(maps.All(stock), inStock); n != 2 {
+		t.Errorf("expected 2, got %d", n)
+	}
+	if !AnySeq2(maps.All(stock), Keys[string, int](HasPrefix("ch"))) {
+		t.Error("expected a key starting with ch")
+	}
+	if AllSeq2(maps.All(stock), inStock) {
+		t.Error("expected some items out of stock")
+	}
+
+	k, v, ok := FindSeq2(maps.All(stock), Keys[string, int](HasPrefix("ban")))
+	if !ok || k != "banana" || v != 12 {
+		t.Errorf("expected (banana, 12, true), got (%s, %d, %v)", k, v, ok)
+	}
+}
+
+func TestPartitionGroupBySeq(t *testing.T) {
+	matched, unmatched := PartitionSeq(slices.Values([]int{1, 2, 3, 4}), IsEven)
+	if !slices.Equal(matched, []int{2, 4}) || !slices.Equal(unmatched, []int{1, 3}) {
+		t.Errorf("expected [2 4] and [1 3], got %v and %v", matched, unmatched)
+	}
+
+	groups := GroupBySeq(slices.Values([]int{1, 2, 3, 4, 5}), IsEven)
+	want := map[bool][]int{true: {2, 4}, false: {1, 3, 5}}
+	if !maps.EqualFunc(groups, want, slices.Equal) {
+		t.Errorf("expected %v, got %v", want, groups)
+	}
+}
+
+func TestTakeWhileSeqIsLazy(t *testing.T) {
+	var pulled int
+	got := slices.Collect(TakeWhileSeq(naturals(&pulled), LessThan(4)))
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+	if pulled != 4 {
+		t.Errorf("expected to stop after pulling 4 values, pulled %d", pulled)
+	}
+}
+
+func TestDropWhileSeq(t *testing.T) {
+	got := slices.Collect(DropWhileSeq(slices.Values([]int{2, 4, 5, 6, 7}), IsEven))
+	if !slices.Equal(got, []int{5, 6, 7}) {
+		t.Errorf("expected [5 6 7], got %v", got)
+	}
+
+	var pulled int
+	for n := range DropWhileSeq(naturals(&pulled), LessThan(10)) {
+		if n != 10 {
+			t.Errorf("expected 10 first, got %d", n)
+		}
+		break
+	}
+}
+
+func TestFindLastIndexOfSeq(t *testing.T) {
+	seq := slices.Values([]int{1, 2, 3, 4, 5})
+	if got, ok := FindLastSeq(seq, IsEven); !ok || got != 4 {
+		t.Errorf("expected (4, true), got (%d, %v)", got, ok)
+	}
+	if _, ok := FindLastSeq(seq, GreaterThan(5)); ok {
+		t.Error("expected no match")
+	}
+	if got := IndexOfSeq(seq, GreaterThan(5)); got != -1 {
+		t.Errorf("expected -1, got %d", got)
+	}
+
+	var pulled int
+	if got := IndexOfSeq(naturals(&pulled), GreaterThan(6)); got != 6 || pulled != 7 {
+		t.Errorf("expected index 6 after pulling 7 values, got %d after %d", got, pulled)
+	}
+}
+
+func TestDistinctBySeq(t *testing.T) {
+	got := slices.Collect(DistinctBySeq(slices.Values([]int{1, 4, 2, 5, 3, 6}), func(n int) int { return n % 3 }))
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+
+	var pulled int
+	for n := range DistinctBySeq(naturals(&pulled), func(n int) int { return n / 10 }) {
+		if n == 20 {
+			break
+		}
+	}
+	if pulled != 20 {
+		t.Errorf("expected to stop after pulling 20 values, pulled %d", pulled)
+	}
+}
+
+func TestChunkSeq(t *testing.T) {
+	var chunks [][]int
+	for chunk := range ChunkSeq(slices.Values([]int{1, 2, 3, 4, 5}), 2) {
+		chunks = append(chunks, chunk)
+	}
+	want := [][]int{{1, 2}, {3, 4}, {5}}
+	if !slices.EqualFunc(chunks, want, slices.Equal) {
+		t.Errorf("expected %v, got %v", want, chunks)
+	}
+
+	var pulled int
+	for chunk := range ChunkSeq(naturals(&pulled), 3) {
+		if !slices.Equal(chunk, []int{1, 2, 3}) {
+			t.Errorf("expected [1 2 3], got %v", chunk)
+		}
+		break
+	}
+	if pulled != 3 {
+		t.Errorf("expected to pull one chunk, pulled %d values", pulled)
+	}
+}
@@ -0,0 +1,181 @@
+package predicate
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// memoizeConfig holds the settings of a memoized predicate.
+type memoizeConfig struct {
+	capacity int
+	ttl      time.Duration
+}
+
+// MemoizeOption is a functional option for configuring Memoize.
+type MemoizeOption func(*memoizeConfig)
+
+// WithCapacity bounds the number of cached results. When the cache is full,
+// the least recently used result is evicted. Values below 1 are ignored.
+// The default is 1024.
+func WithCapacity(n int) MemoizeOption {
+	return func(c *memoizeConfig) {
+		if n > 0 {
+			c.capacity = n
+		}
+	}
+}
+
+// WithTTL makes cached results expire after d, so a changed item is
+// re-evaluated eventually. Values below or equal to zero are ignored.
+// By default results do not expire.
+func WithTTL(d time.Duration) MemoizeOption {
+	return func(c *memoizeConfig) {
+		if d > 0 {
+			c.ttl = d
+		}
+	}
+}
+
+// MemoStats reports how a memoized predicate's cache has been used.
+type MemoStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64 // results dropped to stay within capacity
+	Size      int    // results currently cached
+}
+
+// HitRate returns the fraction of calls answered from the cache.
+func (s MemoStats) HitRate() float64 {
+	if total := s.Hits + s.Misses; total > 0 {
+		return float64(s.Hits) / float64(total)
+	}
+	return 0
+}
+
+// Memoized is a predicate that caches its results per item key.
+// It is safe for concurrent use.
+type Memoized[T any, K comparable] struct {
+	predicate Predicate[T]
+	key       func(T) K
+	config    memoizeConfig
+	now       func() time.Time
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	order   *list.List // front is the most recently used
+	stats   MemoStats
+}
+
+type memoEntry[K comparable] struct {
+	key     K
+	result  bool
+	expires time.Time
+}
+
+// Memoize wraps an expensive predicate so that its result for each item is
+// computed once and then served from a bounded LRU cache. key identifies the
+// item, e.g. func(p Product) int { return p.ID }; items with the same key
+// are assumed to give the same result until it expires.
+//
+// The predicate runs without holding the cache lock, so concurrent misses
+// for the same key may each evaluate it once.
+func Memoize[T any, K comparable](predicate Predicate[T], key func(T) K, opts ...MemoizeOption) *Memoized[T, K] {
+	cfg := memoizeConfig{capacity: 1024}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Memoized[T, K]{
+		predicate: predicate,
+		key:       key,
+		config:    cfg,
+		now:       time.Now,
+		entries:   make(map[K]*list.Element),
+		order:     list.New(),
+	}
+}
+
+// Test reports whether item satisfies the predicate, using the cached
+// result when there is a fresh one.
+func (m *Memoized[T, K]) Test(item T) bool {
+	k := m.key(item)
+	if result, ok := m.lookup(k); ok {
+		return result
+	}
+	result := m.predicate(item)
+	m.store(k, result)
+	return result
+}
+
+// Predicate returns Test as a Predicate, for use with Filter, Any, Count
+// and the other functions of this package.
+func (m *Memoized[T, K]) Predicate() Predicate[T] {
+	return m.Test
+}
+
+// Stats returns a snapshot of the cache statistics.
+func (m *Memoized[T, K]) Stats() MemoStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := m.stats
+	stats.Size = m.order.Len()
+	return stats
+}
+
+// Forget drops the cached result for key, e.g. after the item changed.
+func (m *Memoized[T, K]) Forget(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		m.order.Remove(e)
+		delete(m.entries, key)
+	}
+}
+
+// Reset drops every cached result. Statistics are kept.
+func (m *Memoized[T, K]) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.entries)
+	m.order.Init()
+}
+
+func (m *Memoized[T, K]) lookup(k K) (bool, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[k]
+	if ok {
+		entry := e.Value.(*memoEntry[K])
+		if m.config.ttl == 0 || m.now().Before(entry.expires) {
+			m.order.MoveToFront(e)
+			m.stats.Hits++
+			return entry.result, true
+		}
+		m.order.Remove(e)
+		delete(m.entries, k)
+	}
+	m.stats.Misses++
+	return false, false
+}
+
+func (m *Memoized[T, K]) store(k K, result bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry := &memoEntry[K]{key: k, result: result}
+	if m.config.ttl > 0 {
+		entry.expires = m.now().Add(m.config.ttl)
+	}
+	if e, ok := m.entries[k]; ok {
+		// Another goroutine stored the key while the predicate ran.
+		e.Value = entry
+		m.order.MoveToFront(e)
+		return
+	}
+	m.entries[k] = m.order.PushFront(entry)
+	if m.order.Len() > m.config.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoEntry[K]).key)
+		m.stats.Evictions++
+	}
+}
@@ -0,0 +1,139 @@
+package predicate
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type memoItem struct {
+	ID    int
+	Score int
+}
+
+// scored returns an expensive-looking predicate that counts its calls.
+func scored(calls *atomic.Int64) Predicate[memoItem] {
+	return func(i memoItem) bool {
+		calls.Add(1)
+		return i.Score > 50
+	}
+}
+
+func itemID(i memoItem) int { return i.ID }
+
+func TestMemoizeCachesPerKey(t *testing.T) {
+	var calls atomic.Int64
+	m := Memoize(scored(&calls), itemID)
+	items := []memoItem{{1, 10}, {2, 80}, {3, 60}}
+
+	for range 3 {
+		if got := Count(items, m.Predicate()); got != 2 {
+			t.Fatalf("expected 2 matches, got %d", got)
+		}
+	}
+	if !Any(items, m.Test) {
+		t.Error("expected a match")
+	}
+
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected one evaluation per item, got %d", got)
+	}
+	stats := m.Stats()
+	if stats.Misses != 3 || stats.Hits != 8 || stats.Size != 3 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if rate := stats.HitRate(); rate != 8.0/11 {
+		t.Errorf("expected hit rate 8/11, got %v", rate)
+	}
+}
+
+func TestMemoizeLRUEviction(t *testing.T) {
+	var calls atomic.Int64
+	m := Memoize(scored(&calls), itemID, WithCapacity(2))
+
+	m.Test(memoItem{1, 10})
+	m.Test(memoItem{2, 10})
+	m.Test(memoItem{1, 10}) // 1 is now the most recently used
+	m.Test(memoItem{3, 10}) // evicts 2
+
+	calls.Store(0)
+	m.Test(memoItem{1, 10})
+	m.Test(memoItem{3, 10})
+	if got := calls.Load(); got != 0 {
+		t.Errorf("expected 1 and 3 to be cached, got %d evaluations", got)
+	}
+	m.Test(memoItem{2, 10})
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 2 to have been evicted, got %d evaluations", got)
+	}
+
+	stats := m.Stats()
+	if stats.Evictions != 2 || stats.Size != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	var calls atomic.Int64
+	m := Memoize(scored(&calls), itemID, WithTTL(time.Minute))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	m.Test(memoItem{1, 10})
+	now = now.Add(59 * time.Second)
+	if m.Test(memoItem{1, 90}) {
+		t.Error("expected the cached result before expiry")
+	}
+	now = now.Add(time.Second)
+	if !m.Test(memoItem{1, 90}) {
+		t.Error("expected a fresh result after expiry")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 evaluations, got %d", got)
+	}
+}
+
+func TestMemoizeForgetReset(t *testing.T) {
+	var calls atomic.Int64
+	m := Memoize(scored(&calls), itemID)
+
+	m.Test(memoItem{1, 10})
+	m.Forget(1)
+	if !m.Test(memoItem{1, 90}) {
+		t.Error("expected a fresh result after Forget")
+	}
+	m.Test(memoItem{2, 10})
+	m.Reset()
+	if size := m.Stats().Size; size != 0 {
+		t.Errorf("expected an empty cache after Reset, got %d", size)
+	}
+	m.Test(memoItem{2, 10})
+	if got := calls.Load(); got != 4 {
+		t.Errorf("expected 4 evaluations, got %d", got)
+	}
+}
+
+func TestMemoizeConcurrent(t *testing.T) {
+	var calls atomic.Int64
+	m := Memoize(scored(&calls), itemID, WithCapacity(16), WithTTL(time.Hour))
+
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Go(func() {
+			for i := range 1000 {
+				item := memoItem{ID: (i + w) % 32, Score: (i + w) % 32 * 4}
+				if got, want := m.Test(item), item.Score > 50; got != want {
+					t.Errorf("item %d: expected %v, got %v", item.ID, want, got)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	stats := m.Stats()
+	if stats.Hits+stats.Misses != 8000 || stats.Size > 16 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
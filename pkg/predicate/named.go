@@ -0,0 +1,188 @@
+package predicate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op identifies the kind of a node in a predicate description.
+type Op int
+
+const (
+	OpLeaf Op = iota
+	OpAnd
+	OpOr
+	OpNot
+	OpAtLeast
+	OpExactly
+)
+
+func (o Op) String() string {
+	switch o {
+	case OpLeaf:
+		return "LEAF"
+	case OpAnd:
+		return "AND"
+	case OpOr:
+		return "OR"
+	case OpNot:
+		return "NOT"
+	case OpAtLeast:
+		return "AT LEAST"
+	case OpExactly:
+		return "EXACTLY"
+	}
+	return fmt.Sprintf("Op(%d)", int(o))
+}
+
+// Node is a structured description of a predicate. Leaves carry the name and
+// arguments of the constructor that built them; AND, OR, NOT, AT LEAST and
+// EXACTLY nodes carry their operands as children.
+type Node struct {
+	Op       Op
+	Name     string // leaf name, e.g. "category"
+	Args     []any  // leaf arguments, e.g. ["Electronics"]
+	Format   string // optional fmt layout applied to Args when rendering a leaf
+	Count    int    // threshold of AT LEAST and EXACTLY nodes
+	Children []Node
+}
+
+// String renders the node as a readable expression, e.g.
+// (category = "Electronics" AND in_stock).
+func (n Node) String() string {
+	switch n.Op {
+	case OpLeaf:
+		return n.leafString()
+	case OpNot:
+		return "NOT " + n.Children[0].String()
+	}
+	parts := make([]string, len(n.Children))
+	for i, child := range n.Children {
+		parts[i] = child.String()
+	}
+	return joinExpr(n.Op, n.Count, parts)
+}
+
+// joinExpr renders a composite node from the renderings of its operands:
+// (a AND b), (a OR b), AT LEAST 2 OF (a, b, c) or EXACTLY 1 OF (a, b).
+// An empty AND renders as TRUE and an empty OR as FALSE.
+func joinExpr(op Op, count int, parts []string) string {
+	switch op {
+	case OpAtLeast, OpExactly:
+		return label(op, count) + " (" + strings.Join(parts, ", ") + ")"
+	}
+	if len(parts) == 0 {
+		if op == OpAnd {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	return "(" + strings.Join(parts, " "+op.String()+" ") + ")"
+}
+
+// label names a composite operator, including the threshold if it has one.
+func label(op Op, count int) string {
+	switch op {
+	case OpAtLeast, OpExactly:
+		return fmt.Sprintf("%s %d OF", op, count)
+	}
+	return op.String()
+}
+
+func (n Node) leafString() string {
+	if n.Format != "" {
+		return fmt.Sprintf(n.Format, n.Args...)
+	}
+	if len(n.Args) == 0 {
+		return n.Name
+	}
+	args := make([]string, len(n.Args))
+	for i, arg := range n.Args {
+		if s, ok := arg.(string); ok {
+			args[i] = fmt.Sprintf("%q", s)
+		} else {
+			args[i] = fmt.Sprint(arg)
+		}
+	}
+	return n.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Named is a predicate that carries a description of itself, so it can be
+// logged or inspected instead of printing as a function address.
+// Build leaves with Leaf and compose them with the And, Or and Not methods
+// or with AllOf, AnyOf, NoneOf, AtLeastN and ExactlyN.
+// The zero value is not usable.
+type Named[T any] struct {
+	node     Node
+	test     Predicate[T]
+	operands []Named[T]
+	detail   func(T) string
+}
+
+// Leaf creates a named predicate. The name and arguments identify the
+// condition, e.g. Leaf("min_rating", test, 4.5) renders as min_rating(4.5).
+func Leaf[T any](name string, test Predicate[T], args ...any) Named[T] {
+	return Named[T]{
+		node: Node{Op: OpLeaf, Name: name, Args: args},
+		test: test,
+	}
+}
+
+// WithFormat returns a copy of a leaf that renders its arguments through the
+// given fmt layout, e.g. `rating >= %v`. It has no effect on composed predicates.
+func (n Named[T]) WithFormat(format string) Named[T] {
+	if n.node.Op == OpLeaf {
+		n.node.Format = format
+	}
+	return n
+}
+
+// WithDetail returns a copy of a leaf that describes the value it inspects,
+// e.g. "Price=399.99", when the leaf is explained. It has no effect on
+// composed predicates.
+func (n Named[T]) WithDetail(detail func(T) string) Named[T] {
+	if n.node.Op == OpLeaf {
+		n.detail = detail
+	}
+	return n
+}
+
+// Test reports whether item satisfies the predicate.
+func (n Named[T]) Test(item T) bool {
+	return n.test(item)
+}
+
+// Predicate returns the underlying predicate function.
+func (n Named[T]) Predicate() Predicate[T] {
+	return n.test
+}
+
+// Node returns the structured description of the predicate.
+func (n Named[T]) Node() Node {
+	return n.node
+}
+
+func (n Named[T]) String() string {
+	return n.node.String()
+}
+
+// And combines two named predicates with logical AND. Like AllOf, it
+// flattens chains, so a.And(b).And(c) is a single AND node with three operands.
+func (n Named[T]) And(other Named[T]) Named[T] {
+	return AllOf(n, other)
+}
+
+// Or combines two named predicates with logical OR. Like AnyOf, it flattens
+// chains, so a.Or(b).Or(c) is a single OR node with three operands.
+func (n Named[T]) Or(other Named[T]) Named[T] {
+	return AnyOf(n, other)
+}
+
+// Not negates a named predicate.
+func (n Named[T]) Not() Named[T] {
+	return Named[T]{
+		node:     Node{Op: OpNot, Children: []Node{n.node}},
+		test:     Not(n.test),
+		operands: []Named[T]{n},
+	}
+}
@@ -0,0 +1,74 @@
+package predicate
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNamedString(t *testing.T) {
+	even := Leaf("even", Predicate[int](IsEven))
+	big := Leaf("greater_than", GreaterThan(5), 5).WithFormat("n > %d")
+	between := Leaf("between", Between(1, 3), 1, 3)
+	prefixed := Leaf("prefix", HasPrefix("a"), "a")
+
+	tests := []struct {
+		name string
+		got  fmt.Stringer
+		want string
+	}{
+		{"bare leaf", even, "even"},
+		{"formatted leaf", big, "n > 5"},
+		{"leaf with args", between, "between(1, 3)"},
+		{"string args are quoted", prefixed, `prefix("a")`},
+		{"and", even.And(big), "(even AND n > 5)"},
+		{"or of not", even.Not().Or(between), "(NOT even OR between(1, 3))"},
+		{"nested", even.And(big.Or(between)).Not(), "NOT (even AND (n > 5 OR between(1, 3)))"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNamedTest(t *testing.T) {
+	even := Leaf("even", Predicate[int](IsEven))
+	big := Leaf("big", GreaterThan(5))
+
+	pred := even.And(big.Not()).Or(Leaf("seven", func(n int) bool { return n == 7 }))
+	for n, want := range map[int]bool{2: true, 6: false, 7: true, 3: false} {
+		if got := pred.Test(n); got != want {
+			t.Errorf("%s on %d: expected %v, got %v", pred, n, want, got)
+		}
+	}
+
+	if got := Filter([]int{1, 2, 3, 4, 6, 8}, even.And(big).Predicate()); len(got) != 2 {
+		t.Errorf("expected [6 8], got %v", got)
+	}
+}
+
+func TestNamedNode(t *testing.T) {
+	pred := Leaf("a", Predicate[int](IsEven)).And(Leaf("b", IsOdd, 1).Not())
+	node := pred.Node()
+
+	if node.Op != OpAnd || len(node.Children) != 2 {
+		t.Fatalf("expected AND with two children, got %v with %d", node.Op, len(node.Children))
+	}
+	if leaf := node.Children[0]; leaf.Op != OpLeaf || leaf.Name != "a" {
+		t.Errorf("unexpected first child %+v", leaf)
+	}
+	not := node.Children[1]
+	if not.Op != OpNot || not.Children[0].Name != "b" || not.Children[0].Args[0] != 1 {
+		t.Errorf("unexpected second child %+v", not)
+	}
+}
+
+func TestWithFormatIgnoredOnComposite(t *testing.T) {
+	pred := Leaf("a", Predicate[int](IsEven)).Not().WithFormat("ignored")
+	if got := pred.String(); got != "NOT a" {
+		t.Errorf("expected %q, got %q", "NOT a", got)
+	}
+}
@@ -0,0 +1,196 @@
+package predicate
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelConfig holds the settings shared by the Parallel* functions.
+type parallelConfig struct {
+	workers   int
+	chunkSize int
+}
+
+// ParallelOption is a functional option for configuring the Parallel* functions.
+type ParallelOption func(*parallelConfig)
+
+// WithWorkers sets the number of goroutines used to evaluate the predicate.
+// Values below 1 are ignored. The default is runtime.GOMAXPROCS(0).
+func WithWorkers(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		if n > 0 {
+			c.workers = n
+		}
+	}
+}
+
+// WithChunkSize sets how many consecutive items a worker claims at a time.
+// Smaller chunks balance uneven predicates better and let Any and Find stop
+// sooner; larger chunks reduce coordination overhead. Values below 1 are
+// ignored. By default the input is split evenly across the workers.
+func WithChunkSize(n int) ParallelOption {
+	return func(c *parallelConfig) {
+		if n > 0 {
+			c.chunkSize = n
+		}
+	}
+}
+
+func newParallelConfig(n int, opts []ParallelOption) parallelConfig {
+	cfg := parallelConfig{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.workers > n {
+		cfg.workers = max(n, 1)
+	}
+	if cfg.chunkSize == 0 {
+		cfg.chunkSize = max((n+cfg.workers-1)/cfg.workers, 1)
+	}
+	return cfg
+}
+
+// chunks returns the number of chunks needed to cover n items.
+func (c parallelConfig) chunks(n int) int {
+	return (n + c.chunkSize - 1) / c.chunkSize
+}
+
+// run calls fn for every chunk of [0, n), spreading the chunks over the
+// configured workers. Chunks are claimed in ascending order, and fn can
+// return false to stop the workers from claiming any further chunks.
+func (c parallelConfig) run(n int, fn func(chunk, lo, hi int) bool) {
+	total := c.chunks(n)
+	var next atomic.Int64
+	var stopped atomic.Bool
+	var wg sync.WaitGroup
+
+	for range c.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stopped.Load() {
+				chunk := int(next.Add(1) - 1)
+				if chunk >= total {
+					return
+				}
+				lo := chunk * c.chunkSize
+				hi := min(lo+c.chunkSize, n)
+				if !fn(chunk, lo, hi) {
+					stopped.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// ParallelFilter is like Filter but evaluates the predicate concurrently.
+// The result keeps the original order of items. The predicate must be safe
+// for concurrent use.
+func ParallelFilter[T any](items []T, predicate Predicate[T], opts ...ParallelOption) []T {
+	cfg := newParallelConfig(len(items), opts)
+	if cfg.workers <= 1 {
+		return Filter(items, predicate)
+	}
+
+	parts := make([][]T, cfg.chunks(len(items)))
+	cfg.run(len(items), func(chunk, lo, hi int) bool {
+		parts[chunk] = Filter(items[lo:hi], predicate)
+		return true
+	})
+
+	size := 0
+	for _, part := range parts {
+		size += len(part)
+	}
+	if size == 0 {
+		return nil
+	}
+	result := make([]T, 0, size)
+	for _, part := range parts {
+		result = append(result, part...)
+	}
+	return result
+}
+
+// ParallelCount is like Count but evaluates the predicate concurrently.
+// The predicate must be safe for concurrent use.
+func ParallelCount[T any](items []T, predicate Predicate[T], opts ...ParallelOption) int {
+	cfg := newParallelConfig(len(items), opts)
+	if cfg.workers <= 1 {
+		return Count(items, predicate)
+	}
+
+	var total atomic.Int64
+	cfg.run(len(items), func(_, lo, hi int) bool {
+		total.Add(int64(Count(items[lo:hi], predicate)))
+		return true
+	})
+	return int(total.Load())
+}
+
+// ParallelAny is like Any but evaluates the predicate concurrently.
+// All workers stop as soon as one of them finds a match, even in the middle
+// of a chunk. The predicate must be safe for concurrent use.
+func ParallelAny[T any](items []T, predicate Predicate[T], opts ...ParallelOption) bool {
+	cfg := newParallelConfig(len(items), opts)
+	if cfg.workers <= 1 {
+		return Any(items, predicate)
+	}
+
+	// Unlike Find, any match will do, so no worker has to finish the items
+	// before a match found by another.
+	var found atomic.Bool
+	cfg.run(len(items), func(_, lo, hi int) bool {
+		for i := lo; i < hi && !found.Load(); i++ {
+			if predicate(items[i]) {
+				found.Store(true)
+			}
+		}
+		return !found.Load()
+	})
+	return found.Load()
+}
+
+// ParallelFind is like Find but evaluates the predicate concurrently.
+// It still returns the first matching element in slice order: once a match
+// is found, workers skip every chunk that starts after it.
+// The predicate must be safe for concurrent use.
+func ParallelFind[T any](items []T, predicate Predicate[T], opts ...ParallelOption) (T, bool) {
+	cfg := newParallelConfig(len(items), opts)
+	if cfg.workers <= 1 {
+		return Find(items, predicate)
+	}
+
+	var best atomic.Int64
+	best.Store(int64(len(items)))
+	cfg.run(len(items), func(_, lo, hi int) bool {
+		// Chunks are claimed in order, so once a chunk starts after the best
+		// match every later chunk does too.
+		if int64(lo) > best.Load() {
+			return false
+		}
+		for i := lo; i < hi; i++ {
+			if int64(i) >= best.Load() {
+				return true
+			}
+			if predicate(items[i]) {
+				for {
+					current := best.Load()
+					if int64(i) >= current || best.CompareAndSwap(current, int64(i)) {
+						break
+					}
+				}
+				return true
+			}
+		}
+		return true
+	})
+
+	if i := int(best.Load()); i < len(items) {
+		return items[i], true
+	}
+	var zero T
+	return zero, false
+}
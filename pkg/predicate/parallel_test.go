@@ -0,0 +1,119 @@
+package predicate
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func sequence(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func TestParallelFilterPreservesOrder(t *testing.T) {
+	items := sequence(10_000)
+	divisibleBy7 := func(n int) bool { return n%7 == 0 }
+
+	for _, workers := range []int{1, 2, 3, 8, 64} {
+		got := ParallelFilter(items, divisibleBy7, WithWorkers(workers), WithChunkSize(97))
+		want := Filter(items, divisibleBy7)
+		if !slices.Equal(got, want) {
+			t.Errorf("workers=%d: result differs from Filter", workers)
+		}
+	}
+}
+
+func TestParallelFilterEmpty(t *testing.T) {
+	if got := ParallelFilter(nil, IsEven, WithWorkers(4)); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+	if got := ParallelFilter([]int{1, 3, 5}, IsEven, WithWorkers(4)); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
+
+func TestParallelCount(t *testing.T) {
+	items := sequence(12_345)
+	for _, workers := range []int{1, 4, 16} {
+		if got, want := ParallelCount(items, IsEven, WithWorkers(workers)), Count(items, IsEven); got != want {
+			t.Errorf("workers=%d: expected %d, got %d", workers, want, got)
+		}
+	}
+}
+
+func TestParallelFindReturnsFirstMatch(t *testing.T) {
+	items := sequence(10_000)
+
+	got, ok := ParallelFind(items, GreaterThan(4_321), WithWorkers(8), WithChunkSize(10))
+	if !ok || got != 4_322 {
+		t.Errorf("expected (4322, true), got (%d, %v)", got, ok)
+	}
+
+	_, ok = ParallelFind(items, LessThan(0), WithWorkers(8))
+	if ok {
+		t.Error("expected no match")
+	}
+}
+
+func TestParallelAnyStopsEarly(t *testing.T) {
+	items := sequence(100_000)
+	var calls atomic.Int64
+	isZero := func(n int) bool {
+		calls.Add(1)
+		return n == 0
+	}
+
+	if !ParallelAny(items, isZero, WithWorkers(4), WithChunkSize(100)) {
+		t.Fatal("expected a match")
+	}
+	if n := calls.Load(); n >= int64(len(items)) {
+		t.Errorf("expected early termination, predicate ran %d times", n)
+	}
+	if ParallelAny(items, LessThan(0), WithWorkers(4)) {
+		t.Error("expected no match")
+	}
+}
+
+func TestParallelAnyStopsMidChunk(t *testing.T) {
+	const workers = 4
+	items := sequence(4_000)
+	match := 3_000 // the first item of the last worker's chunk
+	matched := make(chan struct{})
+	var calls atomic.Int64
+	// Every other call waits for the match, so each worker is in the middle
+	// of its chunk when it is found.
+	isMatch := func(n int) bool {
+		calls.Add(1)
+		if n == match {
+			close(matched)
+			return true
+		}
+		<-matched
+		return false
+	}
+
+	if !ParallelAny(items, isMatch, WithWorkers(workers)) {
+		t.Fatal("expected a match")
+	}
+	if n := calls.Load(); n > workers {
+		t.Errorf("expected at most one call per worker, predicate ran %d times", n)
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	items := sequence(1_000_000)
+	for b.Loop() {
+		Filter(items, IsEven)
+	}
+}
+
+func BenchmarkParallelFilter(b *testing.B) {
+	items := sequence(1_000_000)
+	for b.Loop() {
+		ParallelFilter(items, IsEven)
+	}
+}
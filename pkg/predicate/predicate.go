@@ -0,0 +1,83 @@
+package predicate
+
+// Predicate is a generic function that tests a condition on type T.
+type Predicate[T any] func(T) bool
+
+// Filter returns the items that satisfy the predicate.
+func Filter[T any](items []T, predicate Predicate[T]) []T {
+	var result []T
+	for _, item := range items {
+		if predicate(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Any returns true if at least one element satisfies the predicate.
+func Any[T any](items []T, predicate Predicate[T]) bool {
+	for _, item := range items {
+		if predicate(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true if all elements satisfy the predicate.
+func All[T any](items []T, predicate Predicate[T]) bool {
+	for _, item := range items {
+		if !predicate(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// None returns true if no elements satisfy the predicate.
+func None[T any](items []T, predicate Predicate[T]) bool {
+	return !Any(items, predicate)
+}
+
+// Find returns the first element that satisfies the predicate.
+func Find[T any](items []T, predicate Predicate[T]) (T, bool) {
+	for _, item := range items {
+		if predicate(item) {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Count returns the number of elements that satisfy the predicate.
+func Count[T any](items []T, predicate Predicate[T]) int {
+	count := 0
+	for _, item := range items {
+		if predicate(item) {
+			count++
+		}
+	}
+	return count
+}
+
+// And combines two predicates with logical AND.
+func And[T any](p1, p2 Predicate[T]) Predicate[T] {
+	return func(item T) bool {
+		return p1(item) && p2(item)
+	}
+}
+
+// Or combines two predicates with logical OR.
+func Or[T any](p1, p2 Predicate[T]) Predicate[T] {
+	return func(item T) bool {
+		return p1(item) || p2(item)
+	}
+}
+
+// Not negates a predicate.
+func Not[T any](p Predicate[T]) Predicate[T] {
+	return func(item T) bool {
+		return !p(item)
+	}
+}
@@ -0,0 +1,103 @@
+package predicate
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	got := Filter([]int{1, 2, 3, 4, 5, 6}, IsEven)
+	want := []int{2, 4, 6}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestFilterNoMatch(t *testing.T) {
+	got := Filter([]int{1, 3, 5}, IsEven)
+	if len(got) != 0 {
+		t.Errorf("expected empty result, got %v", got)
+	}
+}
+
+func TestAnyAllNone(t *testing.T) {
+	items := []int{1, 2, 3}
+
+	if !Any(items, IsEven) {
+		t.Error("expected Any to find an even number")
+	}
+	if All(items, IsEven) {
+		t.Error("expected All to fail on odd numbers")
+	}
+	if !All(items, IsPositive) {
+		t.Error("expected All positive numbers")
+	}
+	if !None(items, GreaterThan(3)) {
+		t.Error("expected None to be greater than 3")
+	}
+	if None(items, GreaterThan(2)) {
+		t.Error("expected 3 to be greater than 2")
+	}
+}
+
+func TestAllEmpty(t *testing.T) {
+	if !All(nil, IsEven) {
+		t.Error("expected All to be vacuously true for empty input")
+	}
+	if Any(nil, IsEven) {
+		t.Error("expected Any to be false for empty input")
+	}
+}
+
+func TestFind(t *testing.T) {
+	got, ok := Find([]int{1, 3, 4, 6}, IsEven)
+	if !ok || got != 4 {
+		t.Errorf("expected (4, true), got (%d, %v)", got, ok)
+	}
+
+	got, ok = Find([]int{1, 3}, IsEven)
+	if ok || got != 0 {
+		t.Errorf("expected (0, false), got (%d, %v)", got, ok)
+	}
+}
+
+func TestCount(t *testing.T) {
+	if got := Count([]int{1, 2, 3, 4, 5}, IsOdd); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	evenAndBig := And(IsEven, GreaterThan(5))
+	if !evenAndBig(6) || evenAndBig(4) || evenAndBig(7) {
+		t.Error("And did not combine predicates correctly")
+	}
+
+	evenOrBig := Or(IsEven, GreaterThan(5))
+	if !evenOrBig(4) || !evenOrBig(7) || evenOrBig(3) {
+		t.Error("Or did not combine predicates correctly")
+	}
+
+	odd := Not(Predicate[int](IsEven))
+	if !odd(3) || odd(4) {
+		t.Error("Not did not negate the predicate")
+	}
+}
+
+func TestAndShortCircuits(t *testing.T) {
+	called := false
+	spy := func(int) bool {
+		called = true
+		return true
+	}
+
+	And(Predicate[int](IsEven), spy)(3)
+	if called {
+		t.Error("And evaluated the second predicate after the first failed")
+	}
+
+	Or(Predicate[int](IsOdd), spy)(3)
+	if called {
+		t.Error("Or evaluated the second predicate after the first matched")
+	}
+}
@@ -0,0 +1,351 @@
+package predicate
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrQuerySyntax is reported when a query is not well formed.
+	ErrQuerySyntax = errors.New("syntax error")
+	// ErrQueryType is reported when a well-formed query does not fit the
+	// queried type, e.g. an unknown field or a string compared to a number.
+	ErrQueryType = errors.New("type error")
+)
+
+// QueryError describes a problem in a query string, positioned at the
+// 1-based column (in runes) of the offending token.
+// Use errors.Is with ErrQuerySyntax or ErrQueryType to tell the kinds apart.
+type QueryError struct {
+	Kind   error
+	Column int
+	Msg    string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%v at column %d: %s", e.Kind, e.Column, e.Msg)
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Kind
+}
+
+func syntaxError(col int, format string, args ...any) error {
+	return &QueryError{Kind: ErrQuerySyntax, Column: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func typeError(col int, format string, args ...any) error {
+	return &QueryError{Kind: ErrQueryType, Column: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// CompileQuery compiles a SQL-like boolean expression into a predicate over
+// the struct type T (or a pointer to a struct). For example:
+//
+//	age > 25 AND (role = 'admin' OR country IN ('USA', 'UK')) AND NOT active
+//
+// Supported operators are =, != (or <>), <, <=, >, >=, IN, NOT IN, AND, OR
+// and NOT, with parentheses for grouping. A bare field name tests a bool
+// field. Keywords are case-insensitive and strings use single or double quotes.
+//
+// Field names are resolved against the `query` struct tag first and then,
+// case-insensitively, against the Go field name. A tag of `query:"-"` hides
+// a field. Only bool, string, integer and floating point fields can be queried.
+// Fields promoted through an embedded struct pointer can be queried too; while
+// that pointer is nil, every comparison on them is false.
+//
+// Problems in the query are reported as a *QueryError.
+func CompileQuery[T any](query string) (Predicate[T], error) {
+	typ := reflect.TypeFor[T]()
+	structType := typ
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("predicate: cannot query %s: not a struct", typ)
+	}
+
+	node, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	test, err := compileQueryNode(node, queryFields(structType))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(item T) bool {
+		v := reflect.ValueOf(&item).Elem()
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return false
+			}
+			v = v.Elem()
+		}
+		return test(v)
+	}, nil
+}
+
+// queryField is a struct field that can be referenced from a query.
+type queryField struct {
+	name  string
+	index []int
+	typ   reflect.Type
+}
+
+// queryFields indexes the exported fields of a struct by lower-cased query name.
+func queryFields(structType reflect.Type) map[string]queryField {
+	fields := make(map[string]queryField)
+	for _, f := range reflect.VisibleFields(structType) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("query"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		fields[strings.ToLower(name)] = queryField{name: name, index: f.Index, typ: f.Type}
+	}
+	return fields
+}
+
+// value returns the field of v, or false if it is promoted through a nil
+// embedded pointer.
+func (f queryField) value(v reflect.Value) (reflect.Value, bool) {
+	field, err := v.FieldByIndexErr(f.index)
+	return field, err == nil
+}
+
+type valueTest func(reflect.Value) bool
+
+func compileQueryNode(node queryNode, fields map[string]queryField) (valueTest, error) {
+	switch n := node.(type) {
+	case *binaryNode:
+		left, err := compileQueryNode(n.left, fields)
+		if err != nil {
+			return nil, err
+		}
+		right, err := compileQueryNode(n.right, fields)
+		if err != nil {
+			return nil, err
+		}
+		if n.and {
+			return func(v reflect.Value) bool { return left(v) && right(v) }, nil
+		}
+		return func(v reflect.Value) bool { return left(v) || right(v) }, nil
+
+	case *notNode:
+		operand, err := compileQueryNode(n.operand, fields)
+		if err != nil {
+			return nil, err
+		}
+		return func(v reflect.Value) bool { return !operand(v) }, nil
+
+	case *fieldNode:
+		f, err := lookupField(n.name, fields)
+		if err != nil {
+			return nil, err
+		}
+		if f.typ.Kind() != reflect.Bool {
+			return nil, typeError(n.name.col, "field %s is %s, not bool; compare it with a value", f.name, f.typ)
+		}
+		return func(v reflect.Value) bool {
+			field, ok := f.value(v)
+			return ok && field.Bool()
+		}, nil
+
+	case *compareNode:
+		f, err := lookupField(n.field, fields)
+		if err != nil {
+			return nil, err
+		}
+		return compileComparison(f, n.op, n.value)
+
+	case *inNode:
+		f, err := lookupField(n.field, fields)
+		if err != nil {
+			return nil, err
+		}
+		return compileIn(f, n.values, n.negate)
+	}
+	return nil, fmt.Errorf("predicate: unknown query node %T", node)
+}
+
+func lookupField(name token, fields map[string]queryField) (queryField, error) {
+	f, ok := fields[strings.ToLower(name.text)]
+	if !ok {
+		return f, typeError(name.col, "unknown field %q", name.text)
+	}
+	return f, nil
+}
+
+// compareResult maps a comparison operator to a test on the result of cmp.Compare.
+var compareResult = map[string]func(int) bool{
+	"=":  func(c int) bool { return c == 0 },
+	"!=": func(c int) bool { return c != 0 },
+	"<>": func(c int) bool { return c != 0 },
+	"<":  func(c int) bool { return c < 0 },
+	"<=": func(c int) bool { return c <= 0 },
+	">":  func(c int) bool { return c > 0 },
+	">=": func(c int) bool { return c >= 0 },
+}
+
+func compileComparison(f queryField, op, value token) (valueTest, error) {
+	accept := compareResult[op.text]
+	switch f.typ.Kind() {
+	case reflect.Bool:
+		if op.text != "=" && op.text != "!=" && op.text != "<>" {
+			return nil, typeError(op.col, "operator %s is not defined for bool field %s", op.text, f.name)
+		}
+		lit, err := boolLiteral(f, value)
+		if err != nil {
+			return nil, err
+		}
+		equal := op.text == "="
+		return func(v reflect.Value) bool {
+			field, ok := f.value(v)
+			return ok && (field.Bool() == lit) == equal
+		}, nil
+	case reflect.String:
+		lit, err := stringLiteral(f, value)
+		if err != nil {
+			return nil, err
+		}
+		return compareWith(f, lit, accept, reflect.Value.String), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		lit, err := intLiteral(f, value)
+		if err != nil {
+			return nil, err
+		}
+		return compareWith(f, lit, accept, reflect.Value.Int), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		lit, err := uintLiteral(f, value)
+		if err != nil {
+			return nil, err
+		}
+		return compareWith(f, lit, accept, reflect.Value.Uint), nil
+	case reflect.Float32, reflect.Float64:
+		lit, err := floatLiteral(f, value)
+		if err != nil {
+			return nil, err
+		}
+		return compareWith(f, lit, accept, reflect.Value.Float), nil
+	}
+	return nil, unsupportedField(f, value)
+}
+
+func compareWith[V cmp.Ordered](f queryField, lit V, accept func(int) bool, get func(reflect.Value) V) valueTest {
+	return func(v reflect.Value) bool {
+		field, ok := f.value(v)
+		return ok && accept(cmp.Compare(get(field), lit))
+	}
+}
+
+func compileIn(f queryField, values []token, negate bool) (valueTest, error) {
+	switch f.typ.Kind() {
+	case reflect.Bool:
+		return inWith(f, values, negate, boolLiteral, reflect.Value.Bool)
+	case reflect.String:
+		return inWith(f, values, negate, stringLiteral, reflect.Value.String)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return inWith(f, values, negate, intLiteral, reflect.Value.Int)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return inWith(f, values, negate, uintLiteral, reflect.Value.Uint)
+	case reflect.Float32, reflect.Float64:
+		return inWith(f, values, negate, floatLiteral, reflect.Value.Float)
+	}
+	return nil, unsupportedField(f, values[0])
+}
+
+func inWith[V comparable](f queryField, values []token, negate bool,
+	literal func(queryField, token) (V, error), get func(reflect.Value) V) (valueTest, error) {
+	set := make(map[V]struct{}, len(values))
+	for _, value := range values {
+		lit, err := literal(f, value)
+		if err != nil {
+			return nil, err
+		}
+		set[lit] = struct{}{}
+	}
+	return func(v reflect.Value) bool {
+		field, ok := f.value(v)
+		if !ok {
+			return false
+		}
+		_, in := set[get(field)]
+		return in != negate
+	}, nil
+}
+
+func unsupportedField(f queryField, at token) error {
+	return typeError(at.col, "field %s has unsupported type %s", f.name, f.typ)
+}
+
+func mismatch(f queryField, value token) error {
+	return typeError(value.col, "cannot compare %s field %s with %s", f.typ, f.name, value.describe())
+}
+
+func boolLiteral(f queryField, value token) (bool, error) {
+	switch value.kind {
+	case tokTrue:
+		return true, nil
+	case tokFalse:
+		return false, nil
+	}
+	return false, mismatch(f, value)
+}
+
+func stringLiteral(f queryField, value token) (string, error) {
+	if value.kind != tokString {
+		return "", mismatch(f, value)
+	}
+	return value.text, nil
+}
+
+func intLiteral(f queryField, value token) (int64, error) {
+	if value.kind != tokNumber {
+		return 0, mismatch(f, value)
+	}
+	n, err := strconv.ParseInt(value.text, 10, 64)
+	if err != nil {
+		return 0, numberError(f, value)
+	}
+	return n, nil
+}
+
+func uintLiteral(f queryField, value token) (uint64, error) {
+	if value.kind != tokNumber {
+		return 0, mismatch(f, value)
+	}
+	n, err := strconv.ParseUint(value.text, 10, 64)
+	if err != nil {
+		return 0, numberError(f, value)
+	}
+	return n, nil
+}
+
+func floatLiteral(f queryField, value token) (float64, error) {
+	if value.kind != tokNumber {
+		return 0, mismatch(f, value)
+	}
+	n, err := strconv.ParseFloat(value.text, 64)
+	if err != nil {
+		return 0, numberError(f, value)
+	}
+	return n, nil
+}
+
+// numberError distinguishes malformed numbers from valid numbers that do
+// not fit the field, such as 2.5 for an int field.
+func numberError(f queryField, value token) error {
+	if _, err := strconv.ParseFloat(value.text, 64); err != nil {
+		return syntaxError(value.col, "invalid number %q", value.text)
+	}
+	return typeError(value.col, "%s does not fit %s field %s", value.text, f.typ, f.name)
+}
@@ -0,0 +1,348 @@
+package predicate
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind identifies the lexical class of a query token.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+	tokAnd
+	tokOr
+	tokNot
+	tokIn
+	tokTrue
+	tokFalse
+)
+
+var keywords = map[string]tokenKind{
+	"AND":   tokAnd,
+	"OR":    tokOr,
+	"NOT":   tokNot,
+	"IN":    tokIn,
+	"TRUE":  tokTrue,
+	"FALSE": tokFalse,
+}
+
+// token is a lexical unit of a query with its 1-based column.
+type token struct {
+	kind tokenKind
+	text string
+	col  int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokString:
+		return fmt.Sprintf("string '%s'", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// lex splits a query into tokens.
+func lex(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		col := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", col})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", col})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokComma, ",", col})
+			i++
+		case r == '=':
+			tokens = append(tokens, token{tokOp, "=", col})
+			i++
+		case r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				op += string(runes[i+1])
+			}
+			if op == "!" {
+				return nil, syntaxError(col, "unexpected '!', did you mean '!='?")
+			}
+			tokens = append(tokens, token{tokOp, op, col})
+			i += len(op)
+		case r == '\'' || r == '"':
+			text, n, err := lexString(runes[i:], col)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{tokString, text, col})
+			i += n
+		case r == '-' || r == '.' || unicode.IsDigit(r):
+			j := i + 1
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			text := string(runes[i:j])
+			if text == "-" || text == "." {
+				return nil, syntaxError(col, "unexpected %q", text)
+			}
+			tokens = append(tokens, token{tokNumber, text, col})
+			i = j
+		case r == '_' || unicode.IsLetter(r):
+			j := i + 1
+			for j < len(runes) && (runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			text := string(runes[i:j])
+			kind, ok := keywords[strings.ToUpper(text)]
+			if !ok {
+				kind = tokIdent
+			}
+			tokens = append(tokens, token{kind, text, col})
+			i = j
+		default:
+			return nil, syntaxError(col, "unexpected character %q", r)
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(runes) + 1}), nil
+}
+
+// lexString reads a quoted string literal. A doubled quote inside the
+// literal stands for the quote character itself, as in SQL.
+func lexString(runes []rune, col int) (string, int, error) {
+	quote := runes[0]
+	var sb strings.Builder
+	for i := 1; i < len(runes); i++ {
+		if runes[i] != quote {
+			sb.WriteRune(runes[i])
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == quote {
+			sb.WriteRune(quote)
+			i++
+			continue
+		}
+		return sb.String(), i + 1, nil
+	}
+	return "", 0, syntaxError(col, "unterminated string")
+}
+
+// Abstract syntax tree produced by the parser.
+
+type queryNode interface {
+	column() int
+}
+
+type (
+	binaryNode struct {
+		col         int
+		and         bool
+		left, right queryNode
+	}
+	notNode struct {
+		col     int
+		operand queryNode
+	}
+	fieldNode struct {
+		name token
+	}
+	compareNode struct {
+		field token
+		op    token
+		value token
+	}
+	inNode struct {
+		field  token
+		negate bool
+		values []token
+	}
+)
+
+func (n *binaryNode) column() int  { return n.col }
+func (n *notNode) column() int     { return n.col }
+func (n *fieldNode) column() int   { return n.name.col }
+func (n *compareNode) column() int { return n.field.col }
+func (n *inNode) column() int      { return n.field.col }
+
+// parser is a recursive descent parser for the query grammar:
+//
+//	expr       = and { "OR" and }
+//	and        = unary { "AND" unary }
+//	unary      = "NOT" unary | primary
+//	primary    = "(" expr ")" | ident [ op value | [ "NOT" ] "IN" "(" value { "," value } ")" ]
+//	value      = number | string | "TRUE" | "FALSE"
+//	op         = "=" | "!=" | "<>" | "<" | "<=" | ">" | ">="
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parseQuery(query string) (queryNode, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, syntaxError(tok.col, "unexpected %s", tok.describe())
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, syntaxError(tok.col, "expected %s, found %s", what, tok.describe())
+	}
+	return tok, nil
+}
+
+func (p *parser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		op := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{col: op.col, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{col: op.col, and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (queryNode, error) {
+	if p.peek().kind == tokNot {
+		op := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{col: op.col, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (queryNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case tokIdent:
+		return p.parseCondition(tok)
+	default:
+		return nil, syntaxError(tok.col, "expected field name or '(', found %s", tok.describe())
+	}
+}
+
+func (p *parser) parseCondition(field token) (queryNode, error) {
+	switch tok := p.peek(); {
+	case tok.kind == tokOp:
+		op := p.next()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{field: field, op: op, value: value}, nil
+	case tok.kind == tokIn:
+		p.next()
+		return p.parseList(field, false)
+	case tok.kind == tokNot && p.tokens[p.pos+1].kind == tokIn:
+		p.next()
+		p.next()
+		return p.parseList(field, true)
+	case tok.kind == tokNumber || tok.kind == tokString || tok.kind == tokIdent ||
+		tok.kind == tokTrue || tok.kind == tokFalse || tok.kind == tokLParen:
+		return nil, syntaxError(tok.col, "expected operator after %s, found %s", field.text, tok.describe())
+	default:
+		return &fieldNode{name: field}, nil
+	}
+}
+
+func (p *parser) parseList(field token, negate bool) (queryNode, error) {
+	if _, err := p.expect(tokLParen, "'(' after IN"); err != nil {
+		return nil, err
+	}
+	node := &inNode{field: field, negate: negate}
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		node.values = append(node.values, value)
+		if p.peek().kind != tokComma {
+			break
+		}
+		p.next()
+	}
+	if _, err := p.expect(tokRParen, "',' or ')'"); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (p *parser) parseValue() (token, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber, tokString, tokTrue, tokFalse:
+		return tok, nil
+	default:
+		return tok, syntaxError(tok.col, "expected value, found %s", tok.describe())
+	}
+}
@@ -0,0 +1,184 @@
+package predicate
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type queryUser struct {
+	Name    string
+	Age     int
+	Score   float64
+	Visits  uint
+	Active  bool
+	Role    string `query:"role"`
+	Country string `query:"country_code"`
+	Secret  string `query:"-"`
+	Tags    []string
+}
+
+var queryUsers = []queryUser{
+	{Name: "Alice", Age: 25, Score: 9.5, Visits: 10, Active: true, Role: "admin", Country: "USA"},
+	{Name: "Bob", Age: 30, Score: 7.0, Visits: 3, Active: true, Role: "user", Country: "UK"},
+	{Name: "Charlie", Age: 35, Score: 8.2, Visits: 0, Active: false, Role: "admin", Country: "USA"},
+	{Name: "David", Age: 40, Score: 6.1, Visits: 7, Active: false, Role: "user", Country: "Canada"},
+	{Name: "O'Brien", Age: 28, Score: 5.0, Visits: 1, Active: true, Role: "user", Country: "Ireland"},
+}
+
+func queryNames(t *testing.T, query string) []string {
+	t.Helper()
+	pred, err := CompileQuery[queryUser](query)
+	if err != nil {
+		t.Fatalf("CompileQuery(%q): %v", query, err)
+	}
+	var names []string
+	for _, u := range Filter(queryUsers, pred) {
+		names = append(names, u.Name)
+	}
+	return names
+}
+
+func TestCompileQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"age > 25", []string{"Bob", "Charlie", "David", "O'Brien"}},
+		{"AGE >= 35", []string{"Charlie", "David"}},
+		{"age < 30 and active", []string{"Alice", "O'Brien"}},
+		{"age <= 25 OR age = 40", []string{"Alice", "David"}},
+		{"role = 'admin'", []string{"Alice", "Charlie"}},
+		{`role != "admin" AND age <> 28`, []string{"Bob", "David"}},
+		{"name = 'O''Brien'", []string{"O'Brien"}},
+		{"country_code IN ('USA', 'UK')", []string{"Alice", "Bob", "Charlie"}},
+		{"country_code NOT IN ('USA', 'UK')", []string{"David", "O'Brien"}},
+		{"score >= 8", []string{"Alice", "Charlie"}},
+		{"score < 6.5 AND visits > 0", []string{"David", "O'Brien"}},
+		{"active = false", []string{"Charlie", "David"}},
+		{"age IN (25, 40)", []string{"Alice", "David"}},
+		{"NOT NOT active AND role = 'admin'", []string{"Alice"}},
+		{"age > 25 AND (role = 'admin' OR country_code IN ('USA','UK')) AND NOT active", []string{"Charlie"}},
+		{"age > 25 AND role = 'admin' OR age < 26", []string{"Alice", "Charlie"}},
+		{"age > 25 AND (role = 'admin' OR age < 26)", []string{"Charlie"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := queryNames(t, tt.query); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompileQueryPointer(t *testing.T) {
+	pred, err := CompileQuery[*queryUser]("age = 30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pred(&queryUsers[1]) || pred(&queryUsers[0]) {
+		t.Error("pointer predicate evaluated incorrectly")
+	}
+	if pred(nil) {
+		t.Error("expected nil pointer not to match")
+	}
+}
+
+type queryAudit struct {
+	Reviewed bool
+	Reviewer string
+}
+
+type queryDocument struct {
+	Title string
+	*queryAudit
+}
+
+func TestCompileQueryNilEmbeddedPointer(t *testing.T) {
+	docs := []queryDocument{
+		{Title: "draft"},
+		{Title: "final", queryAudit: &queryAudit{Reviewed: true, Reviewer: "ann"}},
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"reviewed", []string{"final"}},
+		{"reviewer = 'ann'", []string{"final"}},
+		{"reviewer != 'bob'", []string{"final"}},
+		{"reviewer NOT IN ('bob')", []string{"final"}},
+		{"reviewed = false", nil},
+		{"NOT reviewed", []string{"draft"}},
+		{"title = 'draft' OR reviewed", []string{"draft", "final"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			pred, err := CompileQuery[queryDocument](tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, d := range Filter(docs, pred) {
+				got = append(got, d.Title)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCompileQueryErrors(t *testing.T) {
+	tests := []struct {
+		query  string
+		kind   error
+		column int
+	}{
+		{"age >", ErrQuerySyntax, 6},
+		{"age > 25 AND", ErrQuerySyntax, 13},
+		{"(age > 25", ErrQuerySyntax, 10},
+		{"age > 25)", ErrQuerySyntax, 9},
+		{"age 25", ErrQuerySyntax, 5},
+		{"role = 'admin", ErrQuerySyntax, 8},
+		{"age ! 25", ErrQuerySyntax, 5},
+		{"age > 2.5.1", ErrQuerySyntax, 7},
+		{"country IN 'USA'", ErrQuerySyntax, 12},
+		{"age IN (25,)", ErrQuerySyntax, 12},
+		{"age # 3", ErrQuerySyntax, 5},
+		{"salary > 10", ErrQueryType, 1},
+		{"secret = 'x'", ErrQueryType, 1},
+		{"age > 'ten'", ErrQueryType, 7},
+		{"age > 2.5", ErrQueryType, 7},
+		{"visits > -1", ErrQueryType, 10},
+		{"role = 5", ErrQueryType, 8},
+		{"active > true", ErrQueryType, 8},
+		{"active = 'yes'", ErrQueryType, 10},
+		{"age = 1 AND name", ErrQueryType, 13},
+		{"tags = 'x'", ErrQueryType, 8},
+		{"country_code IN ('USA', 7)", ErrQueryType, 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := CompileQuery[queryUser](tt.query)
+			var qerr *QueryError
+			if !errors.As(err, &qerr) {
+				t.Fatalf("expected *QueryError, got %v", err)
+			}
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("expected %v, got %v", tt.kind, err)
+			}
+			if qerr.Column != tt.column {
+				t.Errorf("expected column %d, got %d (%v)", tt.column, qerr.Column, err)
+			}
+		})
+	}
+}
+
+func TestCompileQueryNonStruct(t *testing.T) {
+	if _, err := CompileQuery[int]("x > 1"); err == nil {
+		t.Error("expected an error for a non-struct type")
+	}
+}
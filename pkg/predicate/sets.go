@@ -0,0 +1,114 @@
+package predicate
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// SetAccessor reads a set-valued field, such as a slice of tags or the keys
+// of a map, from a value of type T. Its methods build membership and size
+// predicates on that field:
+//
+//	tags := predicate.SliceField(func(p Product) []string { return p.Tags })
+//	office := tags.ContainsAny("office", "desk")
+//
+// The zero value is not usable; create one with SliceField or MapField.
+type SetAccessor[T any, E comparable] struct {
+	members func(T) iter.Seq[E]
+	size    func(T) int
+	// has looks a single member up directly; nil when the field can only be
+	// scanned.
+	has func(T, E) bool
+}
+
+// SliceField creates a SetAccessor over the elements of a slice field.
+// Duplicate elements count once for membership but every element counts
+// towards the size.
+func SliceField[T any, E comparable](get func(T) []E) SetAccessor[T, E] {
+	return SetAccessor[T, E]{
+		members: func(item T) iter.Seq[E] { return slices.Values(get(item)) },
+		size:    func(item T) int { return len(get(item)) },
+	}
+}
+
+// MapField creates a SetAccessor over the keys of a map field. Membership is
+// answered by map lookups rather than by scanning.
+func MapField[T any, K comparable, V any](get func(T) map[K]V) SetAccessor[T, K] {
+	return SetAccessor[T, K]{
+		members: func(item T) iter.Seq[K] { return maps.Keys(get(item)) },
+		size:    func(item T) int { return len(get(item)) },
+		has: func(item T, key K) bool {
+			_, ok := get(item)[key]
+			return ok
+		},
+	}
+}
+
+// Contains matches values whose field has value as a member.
+func (s SetAccessor[T, E]) Contains(value E) Predicate[T] {
+	return s.ContainsAny(value)
+}
+
+// ContainsAny matches values whose field has at least one of values as a
+// member. With no values it matches nothing.
+func (s SetAccessor[T, E]) ContainsAny(values ...E) Predicate[T] {
+	set := toSet(values)
+	return func(item T) bool {
+		if s.has != nil {
+			for v := range set {
+				if s.has(item, v) {
+					return true
+				}
+			}
+			return false
+		}
+		for m := range s.members(item) {
+			if _, ok := set[m]; ok {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// ContainsAll matches values whose field has every one of values as a
+// member. With no values it matches everything.
+func (s SetAccessor[T, E]) ContainsAll(values ...E) Predicate[T] {
+	set := toSet(values)
+	return func(item T) bool {
+		if s.has != nil {
+			for v := range set {
+				if !s.has(item, v) {
+					return false
+				}
+			}
+			return true
+		}
+		found := make(map[E]struct{}, len(set))
+		for m := range s.members(item) {
+			if len(found) == len(set) {
+				break
+			}
+			if _, ok := set[m]; ok {
+				found[m] = struct{}{}
+			}
+		}
+		return len(found) == len(set)
+	}
+}
+
+// ContainsNone matches values whose field has none of values as a member.
+// With no values it matches everything.
+func (s SetAccessor[T, E]) ContainsNone(values ...E) Predicate[T] {
+	return Not(s.ContainsAny(values...))
+}
+
+// SizeBetween matches values whose field has between min and max members,
+// inclusive.
+func (s SetAccessor[T, E]) SizeBetween(min, max int) Predicate[T] {
+	return func(item T) bool {
+		n := s.size(item)
+		return n >= min && n <= max
+	}
+}
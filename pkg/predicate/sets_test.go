@@ -0,0 +1,56 @@
+package predicate
+
+import (
+	"slices"
+	"testing"
+)
+
+type setItem struct {
+	Name   string
+	Tags   []string
+	Labels map[string]string
+}
+
+func TestSetAccessor(t *testing.T) {
+	items := []setItem{
+		{"a", []string{"red", "blue", "red"}, map[string]string{"env": "prod", "team": "core"}},
+		{"b", []string{"green"}, map[string]string{"env": "dev"}},
+		{"c", nil, nil},
+	}
+	tags := SliceField(func(i setItem) []string { return i.Tags })
+	labels := MapField(func(i setItem) map[string]string { return i.Labels })
+
+	tests := []struct {
+		name string
+		pred Predicate[setItem]
+		want []string
+	}{
+		{"contains", tags.Contains("green"), []string{"b"}},
+		{"any", tags.ContainsAny("blue", "green"), []string{"a", "b"}},
+		{"any of nothing", tags.ContainsAny(), nil},
+		{"all", tags.ContainsAll("red", "blue"), []string{"a"}},
+		{"all with duplicates", tags.ContainsAll("red", "red"), []string{"a"}},
+		{"all missing one", tags.ContainsAll("red", "green"), nil},
+		{"all of nothing", tags.ContainsAll(), []string{"a", "b", "c"}},
+		{"none", tags.ContainsNone("red"), []string{"b", "c"}},
+		{"none of nothing", tags.ContainsNone(), []string{"a", "b", "c"}},
+		{"size counts duplicates", tags.SizeBetween(2, 3), []string{"a"}},
+		{"size of empty", tags.SizeBetween(0, 0), []string{"c"}},
+		{"map any", labels.ContainsAny("team", "owner"), []string{"a"}},
+		{"map all", labels.ContainsAll("env"), []string{"a", "b"}},
+		{"map none", labels.ContainsNone("team"), []string{"b", "c"}},
+		{"map size", labels.SizeBetween(1, 1), []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, item := range Filter(items, tt.pred) {
+				got = append(got, item.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package predicate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// True returns a named predicate that is satisfied by every value. It is
+// the empty AND and renders as TRUE.
+func True[T any]() Named[T] {
+	return AllOf[T]()
+}
+
+// False returns a named predicate that is satisfied by no value. It is the
+// empty OR and renders as FALSE.
+func False[T any]() Named[T] {
+	return AnyOf[T]()
+}
+
+// MergeRule combines two operands of an AND into a single equivalent
+// predicate, e.g. price >= 100 and price <= 300 into price BETWEEN 100 AND
+// 300. It reports false when it does not apply to the pair. Rules may return
+// False when the operands contradict each other.
+type MergeRule[T any] func(a, b Named[T]) (Named[T], bool)
+
+// Simplify rewrites a named predicate into an equivalent one that is usually
+// smaller. It
+//   - removes double negations and pushes NOT down to the leaves (De Morgan),
+//   - flattens nested AND and OR nodes,
+//   - removes duplicate operands, identified by their Node,
+//   - folds constants: TRUE and FALSE operands, x AND NOT x, x OR NOT x, and
+//     AT LEAST and EXACTLY nodes that reduce to AND, OR or a constant,
+//   - merges pairs of AND operands with the given rules.
+//
+// Leaves are kept as they are, so the result evaluates with the same leaf
+// predicates and can still be explained.
+func Simplify[T any](n Named[T], rules ...MergeRule[T]) Named[T] {
+	return simplify(n, false, rules)
+}
+
+// CNF simplifies a named predicate and rewrites it in conjunctive normal
+// form: an AND of ORs of leaves and negated leaves. AT LEAST and EXACTLY
+// nodes that do not reduce are kept as atoms. The result can be
+// exponentially larger than the input.
+func CNF[T any](n Named[T], rules ...MergeRule[T]) Named[T] {
+	return normalize(Simplify(n, rules...), OpAnd, OpOr, rules)
+}
+
+// DNF simplifies a named predicate and rewrites it in disjunctive normal
+// form: an OR of ANDs of leaves and negated leaves. AT LEAST and EXACTLY
+// nodes that do not reduce are kept as atoms. The result can be
+// exponentially larger than the input.
+func DNF[T any](n Named[T], rules ...MergeRule[T]) Named[T] {
+	return normalize(Simplify(n, rules...), OpOr, OpAnd, rules)
+}
+
+// simplify returns n, or NOT n if negate is set, in negation normal form.
+func simplify[T any](n Named[T], negate bool, rules []MergeRule[T]) Named[T] {
+	switch n.node.Op {
+	case OpNot:
+		return simplify(n.operands[0], !negate, rules)
+	case OpAnd, OpOr:
+		op := n.node.Op
+		if negate {
+			op = dual(op)
+		}
+		operands := make([]Named[T], len(n.operands))
+		for i, operand := range n.operands {
+			operands[i] = simplify(operand, negate, rules)
+		}
+		return junction(op, operands, rules)
+	case OpAtLeast, OpExactly:
+		return simplifyThreshold(n, negate, rules)
+	}
+	if negate {
+		return n.Not()
+	}
+	return n
+}
+
+// simplifyThreshold reduces AT LEAST and EXACTLY nodes that are equivalent
+// to a constant, an AND or an OR.
+func simplifyThreshold[T any](n Named[T], negate bool, rules []MergeRule[T]) Named[T] {
+	count, total := n.node.Count, len(n.operands)
+	switch {
+	case n.node.Op == OpAtLeast && count <= 0:
+		return constant[T](!negate)
+	case count > total || count < 0:
+		return constant[T](negate)
+	case n.node.Op == OpAtLeast && count == 1:
+		return simplify(AnyOf(n.operands...), negate, rules)
+	case count == total:
+		return simplify(AllOf(n.operands...), negate, rules)
+	case n.node.Op == OpExactly && count == 0:
+		return simplify(AnyOf(n.operands...), !negate, rules)
+	}
+	operands := make([]Named[T], total)
+	for i, operand := range n.operands {
+		operands[i] = simplify(operand, false, rules)
+	}
+	result := threshold(n.node.Op, count, operands)
+	if negate {
+		return result.Not()
+	}
+	return result
+}
+
+// junction builds a simplified AND or OR from operands that are already
+// simplified.
+func junction[T any](op Op, operands []Named[T], rules []MergeRule[T]) Named[T] {
+	var kept []Named[T]
+	seen := make(map[string]bool)
+	for _, operand := range flatten(op, operands) {
+		switch {
+		case isConstant(operand, op == OpAnd):
+			continue // identity: TRUE in an AND, FALSE in an OR
+		case isConstant(operand, op == OpOr):
+			return operand // absorbing: FALSE in an AND, TRUE in an OR
+		}
+		key := nodeKey(operand.node)
+		if seen[key] {
+			continue
+		}
+		if seen[complementKey(operand.node)] {
+			return constant[T](op == OpOr)
+		}
+		seen[key] = true
+		kept = append(kept, operand)
+	}
+	if op == OpOr {
+		return AnyOf(kept...)
+	}
+	if merged := merge(kept, rules); len(merged) < len(kept) {
+		// Merged operands may fold further, e.g. into FALSE.
+		return junction(op, merged, rules)
+	}
+	return AllOf(kept...)
+}
+
+// merge applies the rules to pairs of operands until none applies.
+func merge[T any](operands []Named[T], rules []MergeRule[T]) []Named[T] {
+	if len(rules) == 0 {
+		return operands
+	}
+	operands = append([]Named[T](nil), operands...)
+	for changed := true; changed; {
+		changed = false
+	pairs:
+		for i := 0; i < len(operands); i++ {
+			for j := i + 1; j < len(operands); j++ {
+				for _, rule := range rules {
+					if merged, ok := rule(operands[i], operands[j]); ok {
+						operands[i] = merged
+						operands = append(operands[:j], operands[j+1:]...)
+						changed = true
+						break pairs
+					}
+				}
+			}
+		}
+	}
+	return operands
+}
+
+// normalize distributes inner nodes over outer nodes, e.g. for CNF an OR of
+// ANDs becomes an AND of ORs.
+func normalize[T any](n Named[T], outer, inner Op, rules []MergeRule[T]) Named[T] {
+	switch n.node.Op {
+	case outer:
+		operands := make([]Named[T], len(n.operands))
+		for i, operand := range n.operands {
+			operands[i] = normalize(operand, outer, inner, rules)
+		}
+		return junction(outer, operands, rules)
+	case inner:
+		// Each combination picks one outer operand from every inner operand.
+		combinations := [][]Named[T]{nil}
+		for _, operand := range n.operands {
+			normal := normalize(operand, outer, inner, rules)
+			terms := []Named[T]{normal}
+			if normal.node.Op == outer {
+				terms = normal.operands
+			}
+			var next [][]Named[T]
+			for _, combination := range combinations {
+				for _, term := range terms {
+					next = append(next, append(combination[:len(combination):len(combination)], term))
+				}
+			}
+			combinations = next
+		}
+		clauses := make([]Named[T], len(combinations))
+		for i, combination := range combinations {
+			clauses[i] = junction(inner, combination, rules)
+		}
+		return junction(outer, clauses, rules)
+	}
+	return n
+}
+
+func dual(op Op) Op {
+	if op == OpAnd {
+		return OpOr
+	}
+	return OpAnd
+}
+
+func constant[T any](value bool) Named[T] {
+	if value {
+		return True[T]()
+	}
+	return False[T]()
+}
+
+// isConstant reports whether n is the constant TRUE or FALSE.
+func isConstant[T any](n Named[T], value bool) bool {
+	op := OpOr
+	if value {
+		op = OpAnd
+	}
+	return n.node.Op == op && len(n.node.Children) == 0
+}
+
+// nodeKey identifies a node by its structure; leaves are identified by
+// their name and arguments.
+func nodeKey(n Node) string {
+	var sb strings.Builder
+	writeKey(&sb, n)
+	return sb.String()
+}
+
+func writeKey(sb *strings.Builder, n Node) {
+	if n.Op == OpLeaf {
+		fmt.Fprintf(sb, "%q%#v", n.Name, n.Args)
+		return
+	}
+	fmt.Fprintf(sb, "%s %d(", n.Op, n.Count)
+	for _, child := range n.Children {
+		writeKey(sb, child)
+		sb.WriteString(",")
+	}
+	sb.WriteString(")")
+}
+
+// complementKey returns the key of the negation of n.
+func complementKey(n Node) string {
+	if n.Op == OpNot {
+		return nodeKey(n.Children[0])
+	}
+	return nodeKey(Node{Op: OpNot, Children: []Node{n}})
+}
@@ -0,0 +1,166 @@
+package predicate
+
+import (
+	"testing"
+)
+
+// atLeast, atMost and within are the int leaves merged by mergeBounds.
+func atLeast(n int) Named[int] {
+	return Leaf("at_least", func(v int) bool { return v >= n }, n).WithFormat("n >= %v")
+}
+
+func atMost(n int) Named[int] {
+	return Leaf("at_most", func(v int) bool { return v <= n }, n).WithFormat("n <= %v")
+}
+
+func within(lo, hi int) Named[int] {
+	return Leaf("within", Between(lo, hi), lo, hi).WithFormat("n BETWEEN %v AND %v")
+}
+
+// mergeBounds merges at_least and at_most leaves into within.
+func mergeBounds(a, b Named[int]) (Named[int], bool) {
+	bounds := func(n Named[int]) (lo, hi int, ok bool) {
+		node := n.Node()
+		switch node.Name {
+		case "at_least":
+			return node.Args[0].(int), 1 << 30, true
+		case "at_most":
+			return -1 << 30, node.Args[0].(int), true
+		case "within":
+			return node.Args[0].(int), node.Args[1].(int), true
+		}
+		return 0, 0, false
+	}
+	lo1, hi1, ok1 := bounds(a)
+	lo2, hi2, ok2 := bounds(b)
+	if !ok1 || !ok2 {
+		return a, false
+	}
+	lo, hi := max(lo1, lo2), min(hi1, hi2)
+	if lo > hi {
+		return False[int](), true
+	}
+	return within(lo, hi), true
+}
+
+func TestSimplify(t *testing.T) {
+	a := Leaf("a", Predicate[int](IsEven))
+	b := Leaf("b", Predicate[int](IsPositive))
+	c := Leaf("c", GreaterThan(5))
+
+	tests := []struct {
+		name string
+		pred Named[int]
+		want string
+	}{
+		{"double negation", a.Not().Not(), "a"},
+		{"de morgan and", a.And(b).Not(), "(NOT a OR NOT b)"},
+		{"de morgan or", AnyOf(a, b.Not()).Not(), "(NOT a AND b)"},
+		{"nested de morgan", a.And(b.Or(c)).Not(), "(NOT a OR (NOT b AND NOT c))"},
+		{"duplicates", AllOf(a, b, a, b.And(a)), "(a AND b)"},
+		{"true operand", AllOf(a, True[int](), b), "(a AND b)"},
+		{"false operand", AllOf(a, False[int]()), "FALSE"},
+		{"true in or", AnyOf(a, True[int]()), "TRUE"},
+		{"contradiction", AllOf(a, b, a.Not()), "FALSE"},
+		{"tautology", AnyOf(a.Not().Not(), a.Not()), "TRUE"},
+		{"not true", True[int]().Not(), "FALSE"},
+		{"single operand left", AllOf(a, True[int]()), "a"},
+		{"at least zero", AtLeastN(0, a, b), "TRUE"},
+		{"at least one", AtLeastN(1, a, b), "(a OR b)"},
+		{"at least all", AtLeastN(2, a, b), "(a AND b)"},
+		{"at least too many", AtLeastN(3, a, b), "FALSE"},
+		{"exactly zero", ExactlyN(0, a, b), "(NOT a AND NOT b)"},
+		{"not exactly zero", ExactlyN(0, a, b).Not(), "(a OR b)"},
+		{"threshold kept", AtLeastN(2, a, b.Not().Not(), c).Not(), "NOT AT LEAST 2 OF (a, b, c)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Simplify(tt.pred)
+			if got.String() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			for n := -10; n <= 10; n++ {
+				if got.Test(n) != tt.pred.Test(n) {
+					t.Fatalf("%s disagrees with %s on %d", got, tt.pred, n)
+				}
+			}
+		})
+	}
+}
+
+func TestSimplifyMergeRules(t *testing.T) {
+	even := Leaf("even", Predicate[int](IsEven))
+
+	tests := []struct {
+		name string
+		pred Named[int]
+		want string
+	}{
+		{"bounds", AllOf(atLeast(2), even, atMost(8)), "(n BETWEEN 2 AND 8 AND even)"},
+		{"tightest bounds", AllOf(atLeast(2), atLeast(4), atMost(8), within(0, 6)), "n BETWEEN 4 AND 6"},
+		{"nested", atLeast(2).And(even.And(atMost(8))), "(n BETWEEN 2 AND 8 AND even)"},
+		{"negated bounds are not merged", AllOf(atLeast(2), atMost(8).Not()), "(n >= 2 AND NOT n <= 8)"},
+		{"empty range", AllOf(even, atLeast(9), atMost(3)), "FALSE"},
+		{"or is not merged", AnyOf(atLeast(9), atMost(3)), "(n >= 9 OR n <= 3)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Simplify(tt.pred, mergeBounds)
+			if got.String() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			for n := -10; n <= 10; n++ {
+				if got.Test(n) != tt.pred.Test(n) {
+					t.Fatalf("%s disagrees with %s on %d", got, tt.pred, n)
+				}
+			}
+		})
+	}
+}
+
+func TestNormalForms(t *testing.T) {
+	a := Leaf("a", Predicate[int](IsEven))
+	b := Leaf("b", Predicate[int](IsPositive))
+	c := Leaf("c", GreaterThan(5))
+	d := Leaf("d", LessThan(-5))
+
+	tests := []struct {
+		name string
+		pred Named[int]
+		cnf  string
+		dnf  string
+	}{
+		{"leaf", a, "a", "a"},
+		{"or of ands", a.And(b).Or(c.And(d)),
+			"((a OR c) AND (a OR d) AND (b OR c) AND (b OR d))",
+			"((a AND b) OR (c AND d))"},
+		{"and of ors", a.Or(b).And(c.Or(d)),
+			"((a OR b) AND (c OR d))",
+			"((a AND c) OR (a AND d) OR (b AND c) OR (b AND d))"},
+		{"negation", a.Or(b).And(c).Not(),
+			"((NOT a OR NOT c) AND (NOT b OR NOT c))",
+			"((NOT a AND NOT b) OR NOT c)"},
+		{"tautological clause dropped", a.And(b).Or(a.Not()),
+			"(b OR NOT a)",
+			"((a AND b) OR NOT a)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cnf, dnf := CNF(tt.pred), DNF(tt.pred)
+			if cnf.String() != tt.cnf {
+				t.Errorf("CNF: expected %q, got %q", tt.cnf, cnf)
+			}
+			if dnf.String() != tt.dnf {
+				t.Errorf("DNF: expected %q, got %q", tt.dnf, dnf)
+			}
+			for n := -10; n <= 10; n++ {
+				if want := tt.pred.Test(n); cnf.Test(n) != want || dnf.Test(n) != want {
+					t.Fatalf("normal forms disagree with %s on %d", tt.pred, n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package spec_test
+
+import (
+	"fmt"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+	"github.com/vdntruong/gopatterns/pkg/predicate/spec"
+)
+
+type User struct {
+	Name   string
+	Age    int
+	Active bool
+}
+
+func ExampleAllOf() {
+	users := []User{
+		{Name: "Alice", Age: 25, Active: true},
+		{Name: "Bob", Age: 17, Active: true},
+		{Name: "Charlie", Age: 40, Active: false},
+	}
+
+	adult := spec.FromPredicate(func(u User) bool { return u.Age >= 18 })
+	active := spec.FromPredicate(func(u User) bool { return u.Active })
+
+	canLogIn := spec.AllOf(adult, active)
+	for _, u := range predicate.Filter(users, spec.ToPredicate(canLogIn)) {
+		fmt.Println(u.Name)
+	}
+
+	// Output:
+	// Alice
+}
+
+func ExampleSpecification() {
+	adult := spec.FromPredicate(func(u User) bool { return u.Age >= 18 })
+	active := spec.FromPredicate(func(u User) bool { return u.Active })
+
+	// Method chaining reads like the business rule
+	needsReview := adult.And(active.Not())
+
+	fmt.Println(needsReview.IsSatisfiedBy(User{Name: "Charlie", Age: 40}))
+	fmt.Println(needsReview.IsSatisfiedBy(User{Name: "Alice", Age: 25, Active: true}))
+
+	// Output:
+	// true
+	// false
+}
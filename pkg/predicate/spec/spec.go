@@ -0,0 +1,154 @@
+// Package spec implements the Specification pattern generically: business
+// rules as objects that can be combined with And, Or and Not and evaluated
+// with IsSatisfiedBy.
+package spec
+
+import (
+	"slices"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+
+// Specification is a composable rule that a value of type T may satisfy.
+type Specification[T any] interface {
+	IsSatisfiedBy(T) bool
+	And(Specification[T]) Specification[T]
+	Or(Specification[T]) Specification[T]
+	Not() Specification[T]
+}
+
+// PredicateNode is a leaf specification backed by a predicate function.
+type PredicateNode[T any] struct {
+	Predicate predicate.Predicate[T]
+}
+
+func (s *PredicateNode[T]) IsSatisfiedBy(item T) bool {
+	return s.Predicate(item)
+}
+
+func (s *PredicateNode[T]) And(other Specification[T]) Specification[T] {
+	return And[T](s, other)
+}
+
+func (s *PredicateNode[T]) Or(other Specification[T]) Specification[T] {
+	return Or[T](s, other)
+}
+
+func (s *PredicateNode[T]) Not() Specification[T] {
+	return Not[T](s)
+}
+
+// AndNode is satisfied when every operand is satisfied. Operands are
+// evaluated in order and evaluation stops at the first failure.
+// An AndNode without operands is always satisfied.
+type AndNode[T any] struct {
+	Operands []Specification[T]
+}
+
+func (s *AndNode[T]) IsSatisfiedBy(item T) bool {
+	for _, operand := range s.Operands {
+		if !operand.IsSatisfiedBy(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *AndNode[T]) And(other Specification[T]) Specification[T] {
+	return And[T](s, other)
+}
+
+func (s *AndNode[T]) Or(other Specification[T]) Specification[T] {
+	return Or[T](s, other)
+}
+
+func (s *AndNode[T]) Not() Specification[T] {
+	return Not[T](s)
+}
+
+// OrNode is satisfied when at least one operand is satisfied. Operands are
+// evaluated in order and evaluation stops at the first success.
+// An OrNode without operands is never satisfied.
+type OrNode[T any] struct {
+	Operands []Specification[T]
+}
+
+func (s *OrNode[T]) IsSatisfiedBy(item T) bool {
+	for _, operand := range s.Operands {
+		if operand.IsSatisfiedBy(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *OrNode[T]) And(other Specification[T]) Specification[T] {
+	return And[T](s, other)
+}
+
+func (s *OrNode[T]) Or(other Specification[T]) Specification[T] {
+	return Or[T](s, other)
+}
+
+func (s *OrNode[T]) Not() Specification[T] {
+	return Not[T](s)
+}
+
+// NotNode negates its operand.
+type NotNode[T any] struct {
+	Operand Specification[T]
+}
+
+func (s *NotNode[T]) IsSatisfiedBy(item T) bool {
+	return !s.Operand.IsSatisfiedBy(item)
+}
+
+func (s *NotNode[T]) And(other Specification[T]) Specification[T] {
+	return And[T](s, other)
+}
+
+func (s *NotNode[T]) Or(other Specification[T]) Specification[T] {
+	return Or[T](s, other)
+}
+
+func (s *NotNode[T]) Not() Specification[T] {
+	return s.Operand // Double negation
+}
+
+// And combines two specifications with logical AND.
+func And[T any](left, right Specification[T]) Specification[T] {
+	return &AndNode[T]{Operands: []Specification[T]{left, right}}
+}
+
+// Or combines two specifications with logical OR.
+func Or[T any](left, right Specification[T]) Specification[T] {
+	return &OrNode[T]{Operands: []Specification[T]{left, right}}
+}
+
+// Not negates a specification.
+func Not[T any](s Specification[T]) Specification[T] {
+	return &NotNode[T]{Operand: s}
+}
+
+// AllOf combines any number of specifications with logical AND.
+// With no arguments it is satisfied by every value.
+func AllOf[T any](specs ...Specification[T]) Specification[T] {
+	return &AndNode[T]{Operands: slices.Clone(specs)}
+}
+
+// AnyOf combines any number of specifications with logical OR.
+// With no arguments it is satisfied by no value.
+func AnyOf[T any](specs ...Specification[T]) Specification[T] {
+	return &OrNode[T]{Operands: slices.Clone(specs)}
+}
+
+// FromPredicate adapts a predicate to a Specification.
+func FromPredicate[T any](p predicate.Predicate[T]) Specification[T] {
+	return &PredicateNode[T]{Predicate: p}
+}
+
+// ToPredicate adapts a Specification to a predicate, so it can be used with
+// Filter, Find, Count and the other predicate functions.
+func ToPredicate[T any](s Specification[T]) predicate.Predicate[T] {
+	return s.IsSatisfiedBy
+}
@@ -0,0 +1,106 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+
+func even() Specification[int] {
+	return FromPredicate[int](predicate.IsEven)
+}
+
+func positive() Specification[int] {
+	return FromPredicate[int](predicate.IsPositive)
+}
+
+func TestCombinators(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Specification[int]
+		in   int
+		want bool
+	}{
+		{"and both", even().And(positive()), 4, true},
+		{"and one", even().And(positive()), -4, false},
+		{"or one", even().Or(positive()), 3, true},
+		{"or none", even().Or(positive()), -3, false},
+		{"not", even().Not(), 3, true},
+		{"not of and", even().And(positive()).Not(), 4, false},
+		{"package And", And(even(), positive()), 2, true},
+		{"package Or", Or(even(), positive()), -1, false},
+		{"package Not", Not(positive()), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.spec.IsSatisfiedBy(tt.in); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDoubleNegation(t *testing.T) {
+	s := even()
+	if s.Not().Not() != s {
+		t.Error("expected Not().Not() to return the original specification")
+	}
+}
+
+func TestAllOfAnyOf(t *testing.T) {
+	small := FromPredicate(predicate.LessThan(10))
+
+	all := AllOf(even(), positive(), small)
+	for n, want := range map[int]bool{4: true, 12: false, -2: false, 3: false} {
+		if got := all.IsSatisfiedBy(n); got != want {
+			t.Errorf("AllOf(%d): expected %v, got %v", n, want, got)
+		}
+	}
+
+	any := AnyOf(even(), small)
+	for n, want := range map[int]bool{12: true, 3: true, 13: false} {
+		if got := any.IsSatisfiedBy(n); got != want {
+			t.Errorf("AnyOf(%d): expected %v, got %v", n, want, got)
+		}
+	}
+
+	if !AllOf[int]().IsSatisfiedBy(0) {
+		t.Error("expected empty AllOf to be satisfied")
+	}
+	if AnyOf[int]().IsSatisfiedBy(0) {
+		t.Error("expected empty AnyOf not to be satisfied")
+	}
+}
+
+func TestAllOfShortCircuits(t *testing.T) {
+	calls := 0
+	counted := FromPredicate(func(int) bool {
+		calls++
+		return true
+	})
+
+	AllOf(positive(), counted).IsSatisfiedBy(-1)
+	AnyOf(positive(), counted).IsSatisfiedBy(1)
+	if calls != 0 {
+		t.Errorf("expected no calls after the result was decided, got %d", calls)
+	}
+}
+
+func TestAllOfCopiesOperands(t *testing.T) {
+	operands := []Specification[int]{even(), positive()}
+	s := AllOf(operands...)
+	operands[0] = Not(even())
+
+	if !s.IsSatisfiedBy(2) {
+		t.Error("expected AllOf to be unaffected by later changes to the argument slice")
+	}
+}
+
+func TestPredicateAdapters(t *testing.T) {
+	numbers := []int{-2, -1, 0, 1, 2, 3, 4}
+	got := predicate.Filter(numbers, ToPredicate(even().And(positive())))
+	if len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Errorf("expected [2 4], got %v", got)
+	}
+}
@@ -0,0 +1,31 @@
+package predicate
+
+import "strings"
+
+// HasPrefix creates a predicate that matches strings starting with prefix.
+func HasPrefix(prefix string) Predicate[string] {
+	return func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	}
+}
+
+// HasSuffix creates a predicate that matches strings ending with suffix.
+func HasSuffix(suffix string) Predicate[string] {
+	return func(s string) bool {
+		return strings.HasSuffix(s, suffix)
+	}
+}
+
+// Contains creates a predicate that matches strings containing substring.
+func Contains(substring string) Predicate[string] {
+	return func(s string) bool {
+		return strings.Contains(s, substring)
+	}
+}
+
+// LongerThan creates a predicate that matches strings longer than length bytes.
+func LongerThan(length int) Predicate[string] {
+	return func(s string) bool {
+		return len(s) > length
+	}
+}
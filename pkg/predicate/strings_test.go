@@ -0,0 +1,29 @@
+package predicate
+
+import "testing"
+
+func TestStringPredicates(t *testing.T) {
+	tests := []struct {
+		name string
+		pred Predicate[string]
+		in   string
+		want bool
+	}{
+		{"HasPrefix match", HasPrefix("ba"), "banana", true},
+		{"HasPrefix miss", HasPrefix("ba"), "apple", false},
+		{"HasSuffix match", HasSuffix("rry"), "cherry", true},
+		{"HasSuffix miss", HasSuffix("rry"), "grape", false},
+		{"Contains match", Contains("an"), "banana", true},
+		{"Contains miss", Contains("xy"), "banana", false},
+		{"LongerThan longer", LongerThan(5), "banana", true},
+		{"LongerThan equal", LongerThan(5), "apple", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pred(tt.in); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package predicate
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// MatchesRegexp creates a predicate that matches strings containing a match
+// of re. The expression is compiled once by the caller, so the predicate can
+// be reused across many items without recompiling it.
+func MatchesRegexp(re *regexp.Regexp) Predicate[string] {
+	return re.MatchString
+}
+
+// MatchesPattern compiles pattern and returns a predicate that matches
+// strings containing a match of it, or the compile error.
+func MatchesPattern(pattern string) (Predicate[string], error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return MatchesRegexp(re), nil
+}
+
+// EqualFold creates a predicate that matches strings equal to s under Unicode
+// simple case folding, so "STRASSE" does not match "straße" but "ΣΑΣ" matches
+// "σας".
+func EqualFold(s string) Predicate[string] {
+	return func(v string) bool {
+		return strings.EqualFold(v, s)
+	}
+}
+
+// ContainsFold creates a predicate that matches strings containing substring
+// under Unicode simple case folding, the same folding used by EqualFold.
+func ContainsFold(substring string) Predicate[string] {
+	folded := fold(substring)
+	return func(s string) bool {
+		return strings.Contains(fold(s), folded)
+	}
+}
+
+// HasWord creates a predicate that matches strings with a token equal to word
+// under case folding. Tokens are maximal runs of letters and digits, so
+// HasWord("key") matches "Key Ring" and "key-chain" but not "Keyboard".
+func HasWord(word string) Predicate[string] {
+	folded := fold(word)
+	return func(s string) bool {
+		for _, token := range Tokens(s) {
+			if fold(token) == folded {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasTokenPrefix creates a predicate that matches strings with a token that
+// starts with prefix under case folding, the usual behavior of a search box
+// that completes as the user types: HasTokenPrefix("mon") matches
+// "4K Monitor".
+func HasTokenPrefix(prefix string) Predicate[string] {
+	folded := fold(prefix)
+	return func(s string) bool {
+		for _, token := range Tokens(s) {
+			if strings.HasPrefix(fold(token), folded) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Fuzzy creates a predicate that matches strings within maxDistance edits of
+// target under case folding. Edits are counted by DamerauDistance, so a
+// swapped pair of letters such as "keybaord" costs one edit.
+func Fuzzy(target string, maxDistance int) Predicate[string] {
+	folded := []rune(fold(target))
+	return func(s string) bool {
+		return withinDistance([]rune(fold(s)), folded, maxDistance)
+	}
+}
+
+// FuzzyWord creates a predicate that matches strings with a token within
+// maxDistance edits of word, e.g. FuzzyWord("keybaord", 1) matches
+// "Mechanical Keyboard".
+func FuzzyWord(word string, maxDistance int) Predicate[string] {
+	folded := []rune(fold(word))
+	return func(s string) bool {
+		for _, token := range Tokens(s) {
+			if withinDistance([]rune(fold(token)), folded, maxDistance) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Tokens splits s into maximal runs of letters and digits, the tokens used by
+// HasWord, HasTokenPrefix and FuzzyWord.
+func Tokens(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// EditDistance returns the Levenshtein distance between a and b: the minimum
+// number of single-rune insertions, deletions and substitutions that turn one
+// into the other.
+func EditDistance(a, b string) int {
+	return distance([]rune(a), []rune(b), false)
+}
+
+// DamerauDistance is like EditDistance but also counts the transposition of
+// two adjacent runes as a single edit (the optimal string alignment
+// distance), so DamerauDistance("ab", "ba") is 1 rather than 2.
+func DamerauDistance(a, b string) int {
+	return distance([]rune(a), []rune(b), true)
+}
+
+func withinDistance(a, b []rune, maxDistance int) bool {
+	if maxDistance < 0 || abs(len(a)-len(b)) > maxDistance {
+		return false
+	}
+	return distance(a, b, true) <= maxDistance
+}
+
+// distance computes the edit distance with three rolling rows of the usual
+// dynamic programming table; transpositions need the row before last.
+func distance(a, b []rune, transpose bool) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if transpose && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// fold maps every rune of s to a canonical member of its Unicode simple case
+// folding orbit, so two strings are equal under strings.EqualFold exactly
+// when their folds are equal.
+func fold(s string) string {
+	return strings.Map(foldRune, s)
+}
+
+func foldRune(r rune) rune {
+	canonical := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		canonical = min(canonical, f)
+	}
+	return canonical
+}
@@ -0,0 +1,101 @@
+package predicate
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+)
+
+func TestTextPredicates(t *testing.T) {
+	tests := []struct {
+		name string
+		pred Predicate[string]
+		in   string
+		want bool
+	}{
+		{"MatchesRegexp match", MatchesRegexp(regexp.MustCompile(`^SKU-\d{4}$`)), "SKU-0042", true},
+		{"MatchesRegexp miss", MatchesRegexp(regexp.MustCompile(`^SKU-\d{4}$`)), "SKU-42", false},
+		{"EqualFold ascii", EqualFold("Keyboard"), "KEYBOARD", true},
+		{"EqualFold sigma", EqualFold("ΣΑΣ"), "σας", true},
+		{"EqualFold kelvin", EqualFold("k"), "K", true},
+		{"EqualFold miss", EqualFold("key"), "keyboard", false},
+		{"ContainsFold ascii", ContainsFold("KEY"), "Mechanical keyboard", true},
+		{"ContainsFold unicode", ContainsFold("ΟΔΟΣ"), "Μεγάλη οδος", true},
+		{"ContainsFold final sigma", ContainsFold("σας"), "ΣΑΣ", true},
+		{"ContainsFold miss", ContainsFold("mouse"), "Keyboard", false},
+		{"HasWord match", HasWord("KEY"), "key-chain holder", true},
+		{"HasWord partial", HasWord("key"), "Keyboard", false},
+		{"HasWord unicode", HasWord("café"), "Le CAFÉ du coin", true},
+		{"HasTokenPrefix first", HasTokenPrefix("mech"), "Mechanical Keyboard", true},
+		{"HasTokenPrefix later", HasTokenPrefix("KEY"), "Mechanical Keyboard", true},
+		{"HasTokenPrefix inner", HasTokenPrefix("board"), "Mechanical Keyboard", false},
+		{"Fuzzy transposition", Fuzzy("keybaord", 1), "Keyboard", true},
+		{"Fuzzy substitution", Fuzzy("moniter", 1), "Monitor", true},
+		{"Fuzzy too far", Fuzzy("mouse", 1), "Monitor", false},
+		{"Fuzzy negative", Fuzzy("desk", -1), "desk", false},
+		{"FuzzyWord", FuzzyWord("keybaord", 1), "Mechanical Keyboard", true},
+		{"FuzzyWord exact distance", FuzzyWord("chiar", 1), "Office Chair", true},
+		{"FuzzyWord miss", FuzzyWord("keybaord", 1), "Keyring", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pred(tt.in); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMatchesPattern(t *testing.T) {
+	pred, err := MatchesPattern(`(?i)\bdesk\b`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pred("Standing DESK") || pred("desktop") {
+		t.Error("expected a whole-word, case-insensitive match")
+	}
+
+	if _, err := MatchesPattern(`(unclosed`); err == nil {
+		t.Error("expected an invalid pattern to be rejected")
+	}
+}
+
+func TestEditDistances(t *testing.T) {
+	tests := []struct {
+		a, b             string
+		levenshtein, osa int
+	}{
+		{"", "", 0, 0},
+		{"", "abc", 3, 3},
+		{"kitten", "sitting", 3, 3},
+		{"keybaord", "keyboard", 2, 1},
+		{"ab", "ba", 2, 1},
+		{"ca", "abc", 3, 3},
+		{"héllo", "hello", 1, 1},
+		{"日本語", "日本", 1, 1},
+	}
+
+	for _, tt := range tests {
+		if got := EditDistance(tt.a, tt.b); got != tt.levenshtein {
+			t.Errorf("EditDistance(%q, %q): expected %d, got %d", tt.a, tt.b, tt.levenshtein, got)
+		}
+		if got := EditDistance(tt.b, tt.a); got != tt.levenshtein {
+			t.Errorf("EditDistance(%q, %q): expected %d, got %d", tt.b, tt.a, tt.levenshtein, got)
+		}
+		if got := DamerauDistance(tt.a, tt.b); got != tt.osa {
+			t.Errorf("DamerauDistance(%q, %q): expected %d, got %d", tt.a, tt.b, tt.osa, got)
+		}
+	}
+}
+
+func TestTokens(t *testing.T) {
+	got := Tokens("  4K-Monitor, 27\" (IPS)/café ")
+	want := []string{"4K", "Monitor", "27", "IPS", "café"}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := Tokens("--"); len(got) != 0 {
+		t.Errorf("expected no tokens, got %q", got)
+	}
+}
@@ -0,0 +1,105 @@
+package predicate
+
+import "time"
+
+// Clock returns the current time. Predicates that depend on the current time
+// read it from a Clock, so tests can substitute a fixed one.
+type Clock func() time.Time
+
+// TimeOption configures time predicates that depend on the current time.
+type TimeOption func(*timeConfig)
+
+type timeConfig struct {
+	clock Clock
+}
+
+// WithClock sets the clock used to read the current time. The default is
+// time.Now; a nil clock is ignored.
+func WithClock(clock Clock) TimeOption {
+	return func(c *timeConfig) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
+
+// Before creates a predicate that matches times strictly before t.
+func Before(t time.Time) Predicate[time.Time] {
+	return func(v time.Time) bool {
+		return v.Before(t)
+	}
+}
+
+// After creates a predicate that matches times strictly after t.
+func After(t time.Time) Predicate[time.Time] {
+	return func(v time.Time) bool {
+		return v.After(t)
+	}
+}
+
+// BetweenTimes creates a predicate that matches times in the inclusive range
+// [start, end]. Times are compared as instants, so the zones of start, end
+// and the tested value do not matter.
+func BetweenTimes(start, end time.Time) Predicate[time.Time] {
+	return func(v time.Time) bool {
+		return !v.Before(start) && !v.After(end)
+	}
+}
+
+// WithinLast creates a predicate that matches times in the window (now-d, now].
+// The clock is read on every call, so a long-lived predicate keeps sliding
+// with the current time; times in the future do not match.
+func WithinLast(d time.Duration, opts ...TimeOption) Predicate[time.Time] {
+	config := timeConfig{clock: time.Now}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return func(v time.Time) bool {
+		now := config.clock()
+		return v.After(now.Add(-d)) && !v.After(now)
+	}
+}
+
+// OnWeekday creates a predicate that matches times falling on one of days in
+// loc. The zone matters: 23:30 on a Friday in UTC is already Saturday in
+// Tokyo. With no days it matches nothing.
+func OnWeekday(loc *time.Location, days ...time.Weekday) Predicate[time.Time] {
+	set := toSet(days)
+	return func(v time.Time) bool {
+		_, ok := set[v.In(loc).Weekday()]
+		return ok
+	}
+}
+
+// DuringHours creates a predicate that matches times whose wall clock in loc
+// lies in [from, to), both given as offsets from midnight. When from is after
+// to the range wraps past midnight, so DuringHours(loc, 22*time.Hour,
+// 6*time.Hour) matches night shifts.
+func DuringHours(loc *time.Location, from, to time.Duration) Predicate[time.Time] {
+	return func(v time.Time) bool {
+		offset := timeOfDay(v.In(loc))
+		if from <= to {
+			return offset >= from && offset < to
+		}
+		return offset >= from || offset < to
+	}
+}
+
+// InBusinessHours creates a predicate that matches times from Monday to
+// Friday whose wall clock in loc lies in [open, close), e.g.
+// InBusinessHours(berlin, 9*time.Hour, 17*time.Hour+30*time.Minute).
+func InBusinessHours(loc *time.Location, open, close time.Duration) Predicate[time.Time] {
+	return And(
+		OnWeekday(loc, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday),
+		DuringHours(loc, open, close),
+	)
+}
+
+// timeOfDay returns the wall-clock time of t as an offset from midnight.
+// It is read from the clock fields rather than subtracted from midnight, so
+// days with a daylight saving change still put 09:00 at nine hours.
+func timeOfDay(t time.Time) time.Duration {
+	hour, minute, second := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(t.Nanosecond())
+}
@@ -0,0 +1,152 @@
+package predicate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeComparisons(t *testing.T) {
+	noon := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name string
+		pred Predicate[time.Time]
+		in   time.Time
+		want bool
+	}{
+		{"Before earlier", Before(noon), noon.Add(-time.Second), true},
+		{"Before equal", Before(noon), noon, false},
+		{"After later", After(noon), noon.Add(time.Second), true},
+		{"After equal", After(noon), noon, false},
+		{"BetweenTimes start", BetweenTimes(noon, noon.Add(time.Hour)), noon, true},
+		{"BetweenTimes end", BetweenTimes(noon, noon.Add(time.Hour)), noon.Add(time.Hour), true},
+		{"BetweenTimes outside", BetweenTimes(noon, noon.Add(time.Hour)), noon.Add(2 * time.Hour), false},
+		{"BetweenTimes other zone", BetweenTimes(noon, noon.Add(time.Hour)), time.Date(2025, 3, 14, 21, 30, 0, 0, tokyo), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pred(tt.in); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWithinLast(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	recent := WithinLast(time.Hour, WithClock(clock))
+
+	tests := []struct {
+		name string
+		in   time.Time
+		want bool
+	}{
+		{"now", now, true},
+		{"inside", now.Add(-59 * time.Minute), true},
+		{"window start", now.Add(-time.Hour), false},
+		{"too old", now.Add(-2 * time.Hour), false},
+		{"future", now.Add(time.Second), false},
+	}
+	for _, tt := range tests {
+		if got := recent(tt.in); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	// The clock is read on every call, so the window slides
+	started := now.Add(-30 * time.Minute)
+	if !recent(started) {
+		t.Fatal("expected a match before the clock moves")
+	}
+	now = now.Add(time.Hour)
+	if recent(started) {
+		t.Error("expected no match once the window has moved past it")
+	}
+
+	if !WithinLast(time.Hour, WithClock(nil))(time.Now()) {
+		t.Error("expected a nil clock to fall back to time.Now")
+	}
+}
+
+func TestOnWeekday(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	fridayNight := time.Date(2025, 3, 14, 23, 30, 0, 0, time.UTC) // a Friday
+
+	weekend := OnWeekday(tokyo, time.Saturday, time.Sunday)
+	if !weekend(fridayNight) {
+		t.Error("expected Friday 23:30 UTC to be Saturday in Tokyo")
+	}
+	if OnWeekday(time.UTC, time.Saturday, time.Sunday)(fridayNight) {
+		t.Error("expected Friday 23:30 UTC to be a weekday in UTC")
+	}
+	if OnWeekday(time.UTC)(fridayNight) {
+		t.Error("expected no days to match nothing")
+	}
+}
+
+func TestBusinessHours(t *testing.T) {
+	newYork := time.FixedZone("EST", -5*60*60)
+	open := InBusinessHours(newYork, 9*time.Hour, 17*time.Hour+30*time.Minute)
+
+	tests := []struct {
+		name string
+		in   time.Time
+		want bool
+	}{
+		{"opening", time.Date(2025, 3, 14, 9, 0, 0, 0, newYork), true},
+		{"before opening", time.Date(2025, 3, 14, 8, 59, 59, 0, newYork), false},
+		{"last minute", time.Date(2025, 3, 14, 17, 29, 59, 0, newYork), true},
+		{"closing", time.Date(2025, 3, 14, 17, 30, 0, 0, newYork), false},
+		{"saturday", time.Date(2025, 3, 15, 11, 0, 0, 0, newYork), false},
+		{"utc inside", time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC), true},
+		{"utc outside", time.Date(2025, 3, 14, 13, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := open(tt.in); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	night := DuringHours(time.UTC, 22*time.Hour, 6*time.Hour)
+	for hour, want := range map[int]bool{21: false, 22: true, 2: true, 6: false} {
+		if got := night(time.Date(2025, 3, 14, hour, 0, 0, 0, time.UTC)); got != want {
+			t.Errorf("night shift at %02d:00: expected %v, got %v", hour, want, got)
+		}
+	}
+}
+
+func TestBusinessHoursAcrossDaylightSaving(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	open := InBusinessHours(berlin, 9*time.Hour, 17*time.Hour)
+
+	// Clocks moved forward on 2025-03-30, so 09:00 is 07:00 UTC the next
+	// Monday but 08:00 UTC the Monday before.
+	if !open(time.Date(2025, 3, 31, 7, 0, 0, 0, time.UTC)) {
+		t.Error("expected 09:00 CEST to be open")
+	}
+	if open(time.Date(2025, 3, 24, 7, 0, 0, 0, time.UTC)) {
+		t.Error("expected 08:00 CET to be closed")
+	}
+}
+
+func TestOn(t *testing.T) {
+	type session struct {
+		User     string
+		LastSeen time.Time
+	}
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	sessions := []session{{"ada", now.Add(-time.Minute)}, {"bob", now.Add(-2 * time.Hour)}}
+
+	active := On(func(s session) time.Time { return s.LastSeen },
+		WithinLast(time.Hour, WithClock(func() time.Time { return now })))
+	got := Filter(sessions, active)
+	if len(got) != 1 || got[0].User != "ada" {
+		t.Errorf("expected [ada], got %v", got)
+	}
+}
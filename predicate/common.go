@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
 
 // User represents a user in the system
 type User struct {
@@ -171,37 +175,31 @@ func (r *UserRepository4) GetUsers() []User {
 // Problem: Repeated code, hard to test, easy to make mistakes
 
 // Approach 5: SQL-like string queries
-// Problem: String-based, no type safety, runtime errors
+// Problem: String-based, type errors only surface at runtime
 
 type UserRepository5 struct {
 	users []User
 }
 
+// Query filters users with a SQL-like expression such as
+// "age > 25 AND role = 'admin'". The query is parsed and type-checked
+// against User before any user is tested.
 func (r *UserRepository5) Query(query string) ([]User, error) {
-	// Pseudo implementation - would need a parser
-	var result []User
-
-	// Example: "age > 25 AND role = 'admin'"
-	// Problem: Parse string at runtime, no compile-time safety
-
-	// Very simplified example
-	for _, u := range r.users {
-		// Would need complex parsing logic here
-		result = append(result, u)
+	pred, err := predicate.CompileQuery[User](query)
+	if err != nil {
+		return nil, err
 	}
-
-	return result, nil
+	return predicate.Filter(r.users, pred), nil
 }
 
 // Problem:
-// - No type safety
-// - Runtime errors instead of compile-time
-// - Complex parsing logic needed
-// - SQL injection-like vulnerabilities
+// - No compile-time safety: "age > 'old'" is only rejected when it runs
+// - Needs a whole parser and type checker behind a single method
+// - Queries are opaque strings that are hard to reuse or compose
 
 // Demo function showing problems with traditional approaches
 func DemoCommonApproaches() {
-	fmt.Println("=== Common Filtering Approaches (Without Predicate Pattern) ===\n")
+	fmt.Print("=== Common Filtering Approaches (Without Predicate Pattern) ===\n\n")
 
 	users := []User{
 		{ID: 1, Name: "Alice", Email: "alice@example.com", Age: 25, Active: true, Role: "admin", Country: "USA"},
@@ -255,8 +253,11 @@ func DemoCommonApproaches() {
 	fmt.Println("5. SQL-like string queries:")
 	repo5 := &UserRepository5{users: users}
 	queryResult, _ := repo5.Query("age > 25 AND role = 'admin'")
-	fmt.Printf("   Query would return %d users\n", len(queryResult))
-	fmt.Println("   Problem: No type safety, runtime errors, complex parsing")
+	fmt.Printf("   Query returned %d users\n", len(queryResult))
+	if _, err := repo5.Query("age > 'old'"); err != nil {
+		fmt.Printf("   Query error: %v\n", err)
+	}
+	fmt.Println("   Problem: No compile-time safety, errors only at runtime, complex parsing")
 	fmt.Println()
 
 	fmt.Println("=== The Predicate Pattern solves all these problems! ===")
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+
+// Explaining specifications
+//
+// IsSatisfiedBy only answers true or false. ExplainSpecification walks the
+// same tree and records every node's result, so "why is this process not
+// in my results?" points at the leaf that rejected it.
+
+// processColumns reads the Process field behind each SQL column.
+var processColumns = map[string]func(*Process) any{
+	"id":         func(p *Process) any { return p.ID },
+	"title":      func(p *Process) any { return p.Title },
+	"status":     func(p *Process) any { return p.Status },
+	"priority":   func(p *Process) any { return p.Priority },
+	"owner":      func(p *Process) any { return p.Owner },
+	"cpu_usage":  func(p *Process) any { return p.CPUUsage },
+	"memory":     func(p *Process) any { return p.Memory },
+	"started_at": func(p *Process) any { return p.StartedAt },
+}
+
+// ExplainSpecification evaluates spec against p, following the same
+// short-circuit order as IsSatisfiedBy, and reports the result of every node.
+func ExplainSpecification(spec ProcessSpecification, p *Process) predicate.Explanation {
+	switch s := spec.(type) {
+	case *andSpecification:
+		return explainList(predicate.OpAnd, s.Operands, p)
+	case *orSpecification:
+		return explainList(predicate.OpOr, s.Operands, p)
+	case *notSpecification:
+		return predicate.ExplainNot(ExplainSpecification(s.Operand, p))
+	case *baseSpecification:
+		return predicate.ExplainLeaf(s.describe(), s.IsSatisfiedBy(p), s.detail(p))
+	}
+	return predicate.ExplainLeaf(fmt.Sprintf("%T", spec), spec.IsSatisfiedBy(p), "")
+}
+
+func explainList(op predicate.Op, operands []ProcessSpecification, p *Process) predicate.Explanation {
+	return predicate.ExplainAll(op, len(operands),
+		func(i int) predicate.Explanation { return ExplainSpecification(operands[i], p) },
+		func(i int) predicate.Explanation { return describeSpecification(operands[i]) },
+	)
+}
+
+// describeSpecification renders a specification tree without evaluating it.
+func describeSpecification(spec ProcessSpecification) predicate.Explanation {
+	switch s := spec.(type) {
+	case *andSpecification:
+		return describeList(predicate.OpAnd, s.Operands)
+	case *orSpecification:
+		return describeList(predicate.OpOr, s.Operands)
+	case *notSpecification:
+		operand := describeSpecification(s.Operand)
+		return predicate.Explanation{Op: predicate.OpNot, Expr: "NOT " + operand.Expr, Skipped: true,
+			Children: []predicate.Explanation{operand}}
+	case *baseSpecification:
+		return predicate.Explanation{Op: predicate.OpLeaf, Expr: s.describe(), Skipped: true}
+	}
+	return predicate.Explanation{Op: predicate.OpLeaf, Expr: fmt.Sprintf("%T", spec), Skipped: true}
+}
+
+func describeList(op predicate.Op, operands []ProcessSpecification) predicate.Explanation {
+	e := predicate.Explanation{Op: op, Skipped: true}
+	parts := make([]string, len(operands))
+	for i, operand := range operands {
+		child := describeSpecification(operand)
+		parts[i] = child.Expr
+		e.Children = append(e.Children, child)
+	}
+	switch {
+	case len(parts) > 0:
+		e.Expr = "(" + strings.Join(parts, " "+op.String()+" ") + ")"
+	case op == predicate.OpAnd:
+		e.Expr = "TRUE"
+	default:
+		e.Expr = "FALSE"
+	}
+	return e
+}
+
+// describe renders a leaf from its SQL condition when it has one.
+func (s *baseSpecification) describe() string {
+	if s.condition == nil {
+		return "custom predicate"
+	}
+	if value, ok := s.condition.value.(string); ok {
+		return fmt.Sprintf("%s %s %q", s.condition.column, s.condition.op, value)
+	}
+	return fmt.Sprintf("%s %s %v", s.condition.column, s.condition.op, s.condition.value)
+}
+
+// detail reports the value of the column the leaf compares.
+func (s *baseSpecification) detail(p *Process) string {
+	if s.condition == nil {
+		return ""
+	}
+	get, ok := processColumns[s.condition.column]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s=%v", s.condition.column, get(p))
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate/spec"
+)
+
+func TestExplainSpecification(t *testing.T) {
+	pm := CreateProcessManager()
+	python := pm.GetAll()[1]
+	spec := RunningSpecification().
+		And(HighPrioritySpecification()).
+		And(OwnerSpecification("user1"))
+
+	e := ExplainSpecification(spec, python)
+	if e.Result != spec.IsSatisfiedBy(python) {
+		t.Fatalf("explanation result %v differs from IsSatisfiedBy", e.Result)
+	}
+	if got, want := e.Reason(), "priority >= 5 failed: priority=3"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if owner := e.Children[1]; !owner.Skipped || owner.Expr != `owner = "user1"` {
+		t.Errorf("expected the owner check to be skipped, got %+v", owner)
+	}
+}
+
+func TestExplainSpecificationNotAndOr(t *testing.T) {
+	pm := CreateProcessManager()
+	cpp := pm.GetAll()[2] // stopped, priority 7, user1
+	spec := RunningSpecification().Not().And(OwnerSpecification("user2").Or(HighPrioritySpecification()))
+
+	e := ExplainSpecification(spec, cpp)
+	if !e.Result {
+		t.Fatal("expected the specification to be satisfied")
+	}
+	if got, want := e.Reason(), "priority >= 5 passed: priority=7"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestExplainSpecificationEmptyLists(t *testing.T) {
+	pm := CreateProcessManager()
+	cpp := pm.GetAll()[2] // stopped
+	e := ExplainSpecification(RunningSpecification().And(spec.AllOf[*Process]().And(spec.AnyOf[*Process]())), cpp)
+
+	lists := e.Children[1]
+	if !lists.Skipped || lists.Expr != "(TRUE AND FALSE)" {
+		t.Errorf("expected skipped empty lists rendered as TRUE and FALSE, got %+v", lists)
+	}
+}
+
+func TestExplainProductPredicate(t *testing.T) {
+	monitor := Product{Name: "Monitor", Category: "Electronics", Price: 399.99, InStock: true}
+	pred := ByCategory("Electronics").And(InStock()).And(ByMaxPrice(300))
+
+	if got, want := pred.Explain(monitor).Reason(), "price <= 300 failed: Price=399.99"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
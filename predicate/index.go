@@ -0,0 +1,299 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Secondary indexes
+//
+// Find tests every process against the predicate. FindSpecification can do
+// better when the specification carries column metadata: if a conjunct such
+// as status = "running" or priority >= 5 matches a declared index, only the
+// processes the index returns are tested. Anything else falls back to a scan.
+// Builder queries go through FindBuilder, which takes this path.
+
+var ErrUnknownIndex = errors.New("column cannot be indexed")
+
+// indexConstructors lists the columns that can be indexed: hash indexes for
+// equality on string columns, sorted indexes for ranges on numeric ones.
+var indexConstructors = map[string]func() processIndex{
+	"title":     newHashIndex,
+	"status":    newHashIndex,
+	"owner":     newHashIndex,
+	"priority":  newSortedIndex,
+	"cpu_usage": newSortedIndex,
+	"memory":    newSortedIndex,
+}
+
+// processIndex maps column values to positions in ProcessManager.processes.
+// Lookups return positions in ascending order, so indexed results come back
+// in the same order as a scan.
+type processIndex interface {
+	// build replaces the contents with values[pos] for every position.
+	build(values []any)
+	insert(value any, pos int)
+	remove(value any, pos int)
+	// shift removes the entry at pos and moves every later position down by
+	// one, following the removal of a process.
+	shift(value any, pos int)
+	// lookup returns the positions whose value satisfies "value op arg", or
+	// false if the index cannot answer the comparison.
+	lookup(op string, arg any) ([]int, bool)
+}
+
+// CreateIndex declares a secondary index on a column: a hash index on title,
+// status or owner, or a sorted index on priority, cpu_usage or memory.
+// Declaring the same index twice has no effect. Indexes follow every change
+// made through Add, Upsert, Update and Remove.
+func (pm *ProcessManager) CreateIndex(column string) error {
+	if _, ok := indexConstructors[column]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownIndex, column)
+	}
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if _, exists := pm.indexes[column]; exists {
+		return nil
+	}
+	if pm.indexes == nil {
+		pm.indexes = make(map[string]processIndex)
+	}
+	pm.indexes[column] = pm.buildIndex(column)
+	return nil
+}
+
+// buildIndex indexes the current processes on column.
+func (pm *ProcessManager) buildIndex(column string) processIndex {
+	values := make([]any, len(pm.processes))
+	for pos := range pm.processes {
+		values[pos] = processColumns[column](&pm.processes[pos])
+	}
+	index := indexConstructors[column]()
+	index.build(values)
+	return index
+}
+
+// FindSpecification returns copies of the processes that satisfy spec, in
+// the same order as Find. It uses a declared index when spec, or one of its
+// AND operands, compares an indexed column, and scans every process
+// otherwise.
+func (pm *ProcessManager) FindSpecification(spec ProcessSpecification) []*Process {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	positions, ok := pm.candidates(spec)
+	if !ok {
+		return pm.find(spec.IsSatisfiedBy)
+	}
+	var result []*Process
+	for _, pos := range positions {
+		if p := pm.processes[pos]; spec.IsSatisfiedBy(&p) {
+			result = append(result, &p)
+		}
+	}
+	return result
+}
+
+// FindBuilder runs the builder's query through FindSpecification, so the
+// declared indexes answer it; pm.Find(b.Build()) returns the same processes
+// but always scans. If the builder was misused it returns b.Err without
+// running the query.
+func (pm *ProcessManager) FindBuilder(b *ProcessPredicateBuilder) ([]*Process, error) {
+	if err := b.Err(); err != nil {
+		return nil, err
+	}
+	return pm.FindSpecification(b.Specification()), nil
+}
+
+// candidates returns the positions of a superset of the processes that
+// satisfy spec, or false if the indexes cannot narrow it down. An AND uses
+// its most selective indexed operand; an OR needs every operand indexed.
+func (pm *ProcessManager) candidates(spec ProcessSpecification) ([]int, bool) {
+	switch s := spec.(type) {
+	case *baseSpecification:
+		if s.condition == nil {
+			return nil, false
+		}
+		index, ok := pm.indexes[s.condition.column]
+		if !ok {
+			return nil, false
+		}
+		return index.lookup(s.condition.op, s.condition.value)
+	case *andSpecification:
+		var best []int
+		found := false
+		for _, operand := range s.Operands {
+			if positions, ok := pm.candidates(operand); ok && (!found || len(positions) < len(best)) {
+				best, found = positions, true
+			}
+		}
+		return best, found
+	case *orSpecification:
+		var union []int
+		for _, operand := range s.Operands {
+			positions, ok := pm.candidates(operand)
+			if !ok {
+				return nil, false
+			}
+			union = append(union, positions...)
+		}
+		slices.Sort(union)
+		return slices.Compact(union), true
+	}
+	return nil, false
+}
+
+// hashIndex answers equality lookups on string columns.
+type hashIndex struct {
+	buckets map[any][]int
+}
+
+func newHashIndex() processIndex {
+	return &hashIndex{buckets: make(map[any][]int)}
+}
+
+func (h *hashIndex) build(values []any) {
+	clear(h.buckets)
+	for pos, value := range values {
+		h.buckets[value] = append(h.buckets[value], pos)
+	}
+}
+
+func (h *hashIndex) insert(value any, pos int) {
+	bucket := h.buckets[value]
+	i, _ := slices.BinarySearch(bucket, pos)
+	h.buckets[value] = slices.Insert(bucket, i, pos)
+}
+
+func (h *hashIndex) remove(value any, pos int) {
+	bucket := h.buckets[value]
+	if i, found := slices.BinarySearch(bucket, pos); found {
+		bucket = slices.Delete(bucket, i, i+1)
+	}
+	if len(bucket) == 0 {
+		delete(h.buckets, value)
+		return
+	}
+	h.buckets[value] = bucket
+}
+
+func (h *hashIndex) shift(value any, pos int) {
+	h.remove(value, pos)
+	for _, bucket := range h.buckets {
+		// Buckets are ascending, so only a tail needs to move
+		i, _ := slices.BinarySearch(bucket, pos)
+		for ; i < len(bucket); i++ {
+			bucket[i]--
+		}
+	}
+}
+
+func (h *hashIndex) lookup(op string, arg any) ([]int, bool) {
+	if op != "=" {
+		return nil, false
+	}
+	return h.buckets[arg], true
+}
+
+// sortedIndex answers equality and range lookups on numeric columns.
+type sortedIndex struct {
+	entries []sortedEntry // ordered by value, then position
+}
+
+type sortedEntry struct {
+	value float64
+	pos   int
+}
+
+func newSortedIndex() processIndex {
+	return &sortedIndex{}
+}
+
+func compareEntries(a, b sortedEntry) int {
+	return cmp.Or(cmp.Compare(a.value, b.value), cmp.Compare(a.pos, b.pos))
+}
+
+// build sorts all entries at once; inserting them one by one would move
+// the tail of the slice for every process.
+func (s *sortedIndex) build(values []any) {
+	s.entries = make([]sortedEntry, len(values))
+	for pos, value := range values {
+		s.entries[pos] = sortedEntry{value: toFloat(value), pos: pos}
+	}
+	slices.SortFunc(s.entries, compareEntries)
+}
+
+func (s *sortedIndex) insert(value any, pos int) {
+	entry := sortedEntry{value: toFloat(value), pos: pos}
+	i, _ := slices.BinarySearchFunc(s.entries, entry, compareEntries)
+	s.entries = slices.Insert(s.entries, i, entry)
+}
+
+func (s *sortedIndex) remove(value any, pos int) {
+	entry := sortedEntry{value: toFloat(value), pos: pos}
+	if i, found := slices.BinarySearchFunc(s.entries, entry, compareEntries); found {
+		s.entries = slices.Delete(s.entries, i, i+1)
+	}
+}
+
+// shift keeps the entries ordered: moving every later position down by one
+// preserves their order relative to each other and to earlier positions.
+func (s *sortedIndex) shift(value any, pos int) {
+	s.remove(value, pos)
+	for i := range s.entries {
+		if s.entries[i].pos > pos {
+			s.entries[i].pos--
+		}
+	}
+}
+
+func (s *sortedIndex) lookup(op string, arg any) ([]int, bool) {
+	v, ok := numeric(arg)
+	if !ok {
+		return nil, false
+	}
+	lower := sort.Search(len(s.entries), func(i int) bool { return s.entries[i].value >= v })
+	upper := sort.Search(len(s.entries), func(i int) bool { return s.entries[i].value > v })
+
+	var matched []sortedEntry
+	switch op {
+	case "=":
+		matched = s.entries[lower:upper]
+	case "<":
+		matched = s.entries[:lower]
+	case "<=":
+		matched = s.entries[:upper]
+	case ">":
+		matched = s.entries[upper:]
+	case ">=":
+		matched = s.entries[lower:]
+	default:
+		return nil, false
+	}
+
+	positions := make([]int, len(matched))
+	for i, entry := range matched {
+		positions[i] = entry.pos
+	}
+	slices.Sort(positions)
+	return positions, true
+}
+
+func numeric(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func toFloat(value any) float64 {
+	v, _ := numeric(value)
+	return v
+}
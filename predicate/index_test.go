@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func indexedManager(t *testing.T, columns ...string) *ProcessManager {
+	t.Helper()
+	pm := CreateProcessManager()
+	for _, column := range columns {
+		if err := pm.CreateIndex(column); err != nil {
+			t.Fatalf("CreateIndex(%q): %v", column, err)
+		}
+	}
+	return pm
+}
+
+func ids(processes []*Process) []int {
+	result := make([]int, len(processes))
+	for i, p := range processes {
+		result[i] = p.ID
+	}
+	return result
+}
+
+func TestFindSpecificationMatchesScan(t *testing.T) {
+	pm := indexedManager(t, "status", "owner", "title", "priority", "cpu_usage", "memory")
+
+	builders := map[string]*ProcessPredicateBuilder{
+		"status":           NewProcessPredicateBuilder().WithStatus("running"),
+		"status and owner": NewProcessPredicateBuilder().WithStatus("running").WithOwner("user1"),
+		"range":            NewProcessPredicateBuilder().WithMinPriority(5),
+		"mixed":            NewProcessPredicateBuilder().WithMaxCPU(20).WithMinMemory(1024),
+		"or":               NewProcessPredicateBuilder().UseOR().WithOwner("user2").WithTitle("Go"),
+		"unindexed":        NewProcessPredicateBuilder().WithID(4),
+		"missing value":    NewProcessPredicateBuilder().WithStatus("paused"),
+		"empty":            NewProcessPredicateBuilder(),
+	}
+
+	for name, b := range builders {
+		t.Run(name, func(t *testing.T) {
+			want := ids(pm.Find(b.Build()))
+			if got := ids(pm.FindSpecification(b.Specification())); !slices.Equal(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+			found, err := pm.FindBuilder(b)
+			if got := ids(found); err != nil || !slices.Equal(got, want) {
+				t.Errorf("expected FindBuilder to return %v, got %v (%v)", want, got, err)
+			}
+		})
+	}
+}
+
+func TestFindBuilderRejectsMisuse(t *testing.T) {
+	pm := indexedManager(t, "owner")
+	b := NewProcessPredicateBuilder().WithOwner("user1").WithOwner("user2").UseOR()
+	if found, err := pm.FindBuilder(b); !errors.Is(err, ErrMixedCombinators) || found != nil {
+		t.Errorf("expected ErrMixedCombinators and no processes, got %v (%v)", ids(found), err)
+	}
+}
+
+func TestCandidatesUseIndexes(t *testing.T) {
+	pm := indexedManager(t, "status", "owner", "priority")
+
+	tests := []struct {
+		name string
+		spec ProcessSpecification
+		want []int // positions, or nil for a scan
+	}{
+		{"hash", statusLeaf("stopped"), []int{2, 5}},
+		{"most selective conjunct", NewProcessPredicateBuilder().WithStatus("running").WithOwner("user3").Specification(), []int{3}},
+		{"range", minPriorityLeaf(6), []int{2, 4}},
+		{"or of indexed", ownerLeaf("user3").Or(statusLeaf("stopped")), []int{2, 3, 5}},
+		{"or with unindexed", ownerLeaf("user3").Or(titleLeaf("Go")), nil},
+		{"unindexed column", titleLeaf("Go"), nil},
+		{"opaque leaf", NewSpecification(ByStatus("running")), nil},
+		{"negation", statusLeaf("running").Not(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := pm.candidates(tt.spec)
+			if ok != (tt.want != nil) || !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v (indexed: %v)", tt.want, got, ok)
+			}
+		})
+	}
+}
+
+func TestSortedIndexOperators(t *testing.T) {
+	index := newSortedIndex()
+	for pos, v := range []int{5, 3, 7, 3, 9} {
+		index.insert(v, pos)
+	}
+
+	tests := map[string][]int{
+		"=":  {1, 3},
+		"<":  {},
+		"<=": {1, 3},
+		">":  {0, 2, 4},
+		">=": {0, 1, 2, 3, 4},
+	}
+	for op, want := range tests {
+		if got, ok := index.lookup(op, 3); !ok || !slices.Equal(got, want) {
+			t.Errorf("%s 3: expected %v, got %v (%v)", op, want, got, ok)
+		}
+	}
+	if _, ok := index.lookup("!=", 3); ok {
+		t.Error("expected != to fall back to a scan")
+	}
+}
+
+func TestIndexesFollowChanges(t *testing.T) {
+	pm := indexedManager(t, "status", "cpu_usage")
+
+	if !pm.Update(3, func(p *Process) { p.Status = "running"; p.CPUUsage = 50 }) {
+		t.Fatal("expected process 3 to be updated")
+	}
+	if err := pm.Add(Process{ID: 7, Title: "Zig", Status: "stopped", Owner: "user4", CPUUsage: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := ids(pm.FindSpecification(statusLeaf("stopped")))
+	if !slices.Equal(stopped, []int{6, 7}) {
+		t.Errorf("expected stopped [6 7], got %v", stopped)
+	}
+	aboveCPU := processLeaf(func(p *Process) bool { return p.CPUUsage > 45 }, "cpu_usage", ">", 45.0)
+	busy := ids(pm.FindSpecification(aboveCPU))
+	if !slices.Equal(busy, []int{3, 4}) {
+		t.Errorf("expected busy [3 4], got %v", busy)
+	}
+
+	if pm.Update(99, func(*Process) {}) {
+		t.Error("expected an unknown ID to be reported")
+	}
+}
+
+func TestCreateIndexErrors(t *testing.T) {
+	pm := CreateProcessManager()
+	if err := pm.CreateIndex("id"); !errors.Is(err, ErrUnknownIndex) {
+		t.Errorf("expected ErrUnknownIndex, got %v", err)
+	}
+	if err := pm.CreateIndex("status"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.CreateIndex("status"); err != nil {
+		t.Errorf("expected a repeated declaration to be ignored, got %v", err)
+	}
+}
@@ -2,178 +2,198 @@ package main
 
 import (
 	"fmt"
+	"maps"
+	"math"
+	"slices"
 	"strings"
-)
+	"time"
 
-// Predicate is a generic function that tests a condition on type T
-type Predicate[T any] func(T) bool
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
 
-// Filter applies a predicate to filter a slice
-func Filter[T any](items []T, predicate Predicate[T]) []T {
-	var result []T
-	for _, item := range items {
-		if predicate(item) {
-			result = append(result, item)
-		}
-	}
-	return result
+// Product represents a product for demonstration
+//
+// The pred tags select the constructors and builder methods generated into
+// product_predicates_gen.go.
+//
+//go:generate go run ../cmd/predicategen -type Product
+type Product struct {
+	ID       int      `pred:"eq,in"`
+	Name     string   `pred:"eq"`
+	Category string   `pred:"eq,ne,in"`
+	Price    float64  `pred:"ge,le,between"`
+	InStock  bool     `pred:"eq"`
+	Rating   float64  `pred:"ge"`
+	Tags     []string // not generated: slices are not ordered
+	Supplier string   `pred:"eq,in"`
 }
 
-// Any returns true if at least one element satisfies the predicate
-func Any[T any](items []T, predicate Predicate[T]) bool {
-	for _, item := range items {
-		if predicate(item) {
-			return true
-		}
-	}
-	return false
+func (p Product) String() string {
+	return fmt.Sprintf("{ID: %d, Name: %s, Price: $%.2f, InStock: %v, Rating: %.1f}",
+		p.ID, p.Name, p.Price, p.InStock, p.Rating)
 }
 
-// All returns true if all elements satisfy the predicate
-func All[T any](items []T, predicate Predicate[T]) bool {
-	for _, item := range items {
-		if !predicate(item) {
-			return false
-		}
-	}
-	return true
-}
+// Product predicates
+//
+// Each constructor returns a named leaf, so composed filters can be printed,
+// e.g. (category = "Electronics" AND in_stock).
+
+// Field accessors shared by the constructors below.
+var (
+	productName     = predicate.Field(func(p Product) string { return p.Name })
+	productCategory = predicate.Field(func(p Product) string { return p.Category })
+	productSupplier = predicate.Field(func(p Product) string { return p.Supplier })
+	productPrice    = predicate.Field(func(p Product) float64 { return p.Price })
+	productRating   = predicate.Field(func(p Product) float64 { return p.Rating })
+	productTags     = predicate.SliceField(func(p Product) []string { return p.Tags })
+)
 
-// None returns true if no elements satisfy the predicate
-func None[T any](items []T, predicate Predicate[T]) bool {
-	return !Any(items, predicate)
+// ByCategory creates a predicate that filters by category
+func ByCategory(category string) predicate.Named[Product] {
+	return predicate.Leaf("category", productCategory.Eq(category), category).WithFormat("category = %q").
+		WithDetail(func(p Product) string { return fmt.Sprintf("Category=%s", p.Category) })
 }
 
-// Find returns the first element that satisfies the predicate
-func Find[T any](items []T, predicate Predicate[T]) (T, bool) {
-	for _, item := range items {
-		if predicate(item) {
-			return item, true
-		}
-	}
-	var zero T
-	return zero, false
+// ByPriceRange creates a predicate that filters by price range
+func ByPriceRange(min, max float64) predicate.Named[Product] {
+	return predicate.Leaf("price_range", productPrice.Between(min, max), min, max).WithFormat("price BETWEEN %v AND %v").
+		WithDetail(priceDetail)
 }
 
-// Count returns the number of elements that satisfy the predicate
-func Count[T any](items []T, predicate Predicate[T]) int {
-	count := 0
-	for _, item := range items {
-		if predicate(item) {
-			count++
-		}
-	}
-	return count
+// InStock creates a predicate for in-stock products
+func InStock() predicate.Named[Product] {
+	return predicate.Leaf("in_stock", func(p Product) bool {
+		return p.InStock
+	}).WithDetail(func(p Product) string { return fmt.Sprintf("InStock=%v", p.InStock) })
 }
 
-// Predicate combinators
-
-// And combines two predicates with logical AND
-func And[T any](p1, p2 Predicate[T]) Predicate[T] {
-	return func(item T) bool {
-		return p1(item) && p2(item)
-	}
+// ByMinRating creates a predicate for minimum rating
+func ByMinRating(minRating float64) predicate.Named[Product] {
+	return predicate.Leaf("min_rating", productRating.Ge(minRating), minRating).WithFormat("rating >= %v").
+		WithDetail(func(p Product) string { return fmt.Sprintf("Rating=%.1f", p.Rating) })
 }
 
-// Or combines two predicates with logical OR
-func Or[T any](p1, p2 Predicate[T]) Predicate[T] {
-	return func(item T) bool {
-		return p1(item) || p2(item)
-	}
+// ByNameContains creates a predicate for case-insensitive name search
+func ByNameContains(substring string) predicate.Named[Product] {
+	return predicate.Leaf("name_contains", productName.Matches(predicate.ContainsFold(substring)), substring).
+		WithFormat("name CONTAINS %q").WithDetail(nameDetail)
 }
 
-// Not negates a predicate
-func Not[T any](p Predicate[T]) Predicate[T] {
-	return func(item T) bool {
-		return !p(item)
-	}
+// ByNameWord creates a predicate for names with a word starting with prefix,
+// like a search box that completes as the user types
+func ByNameWord(prefix string) predicate.Named[Product] {
+	return predicate.Leaf("name_word", productName.Matches(predicate.HasTokenPrefix(prefix)), prefix).
+		WithFormat("name HAS WORD %q*").WithDetail(nameDetail)
 }
 
-// Product represents a product for demonstration
-type Product struct {
-	ID       int
-	Name     string
-	Category string
-	Price    float64
-	InStock  bool
-	Rating   float64
-	Tags     []string
-	Supplier string
+// ByNameFuzzy creates a predicate for names with a word within maxDistance
+// typos of word
+func ByNameFuzzy(word string, maxDistance int) predicate.Named[Product] {
+	return predicate.Leaf("name_fuzzy", productName.Matches(predicate.FuzzyWord(word, maxDistance)), word, maxDistance).
+		WithFormat("name ~ %q (distance <= %d)").WithDetail(nameDetail)
 }
 
-func (p Product) String() string {
-	return fmt.Sprintf("{ID: %d, Name: %s, Price: $%.2f, InStock: %v, Rating: %.1f}",
-		p.ID, p.Name, p.Price, p.InStock, p.Rating)
+// nameDetail reports the name a leaf inspected when it is explained.
+func nameDetail(p Product) string {
+	return fmt.Sprintf("Name=%s", p.Name)
 }
 
-// Product predicates
-
-// ByCategory creates a predicate that filters by category
-func ByCategory(category string) Predicate[Product] {
-	return func(p Product) bool {
-		return p.Category == category
-	}
+// BySupplier creates a predicate for filtering by supplier
+func BySupplier(supplier string) predicate.Named[Product] {
+	return predicate.Leaf("supplier", productSupplier.Eq(supplier), supplier).WithFormat("supplier = %q").
+		WithDetail(func(p Product) string { return fmt.Sprintf("Supplier=%s", p.Supplier) })
 }
 
-// ByPriceRange creates a predicate that filters by price range
-func ByPriceRange(min, max float64) Predicate[Product] {
-	return func(p Product) bool {
-		return p.Price >= min && p.Price <= max
-	}
+// HasTag creates a predicate for checking if product has a tag
+func HasTag(tag string) predicate.Named[Product] {
+	return predicate.Leaf("tag", productTags.Contains(tag), tagArg(tag)).WithFormat("tags HAS %q").
+		WithDetail(tagsDetail)
 }
 
-// InStock creates a predicate for in-stock products
-func InStock() Predicate[Product] {
-	return func(p Product) bool {
-		return p.InStock
-	}
+// HasAnyTag creates a predicate for products with at least one of tags
+func HasAnyTag(tags ...string) predicate.Named[Product] {
+	return predicate.Leaf("tags_any", productTags.ContainsAny(tags...), tagArgs(slices.Clone(tags))).
+		WithFormat("tags HAS ANY %q").WithDetail(tagsDetail)
 }
 
-// ByMinRating creates a predicate for minimum rating
-func ByMinRating(minRating float64) Predicate[Product] {
-	return func(p Product) bool {
-		return p.Rating >= minRating
-	}
+// HasAllTags creates a predicate for products with every one of tags
+func HasAllTags(tags ...string) predicate.Named[Product] {
+	return predicate.Leaf("tags_all", productTags.ContainsAll(tags...), tagArgs(slices.Clone(tags))).
+		WithFormat("tags HAS ALL %q").WithDetail(tagsDetail)
 }
 
-// ByNameContains creates a predicate for name search
-func ByNameContains(substring string) Predicate[Product] {
-	return func(p Product) bool {
-		return strings.Contains(strings.ToLower(p.Name), strings.ToLower(substring))
-	}
+// HasNoTags creates a predicate for products with none of tags
+func HasNoTags(tags ...string) predicate.Named[Product] {
+	return predicate.Leaf("tags_none", productTags.ContainsNone(tags...), slices.Clone(tags)).
+		WithFormat("tags HAS NONE %q").WithDetail(tagsDetail)
 }
 
-// BySupplier creates a predicate for filtering by supplier
-func BySupplier(supplier string) Predicate[Product] {
-	return func(p Product) bool {
-		return p.Supplier == supplier
-	}
+// ByTagCount creates a predicate for products with between min and max tags
+func ByTagCount(min, max int) predicate.Named[Product] {
+	return predicate.Leaf("tag_count", productTags.SizeBetween(min, max), min, max).
+		WithFormat("len(tags) BETWEEN %v AND %v").WithDetail(tagsDetail)
 }
 
-// HasTag creates a predicate for checking if product has a tag
-func HasTag(tag string) Predicate[Product] {
-	return func(p Product) bool {
-		for _, t := range p.Tags {
-			if t == tag {
-				return true
-			}
-		}
-		return false
-	}
+// tagsDetail reports the tags a leaf inspected when it is explained.
+func tagsDetail(p Product) string {
+	return fmt.Sprintf("Tags=%v", p.Tags)
 }
 
 // ByMaxPrice creates a predicate for maximum price
-func ByMaxPrice(maxPrice float64) Predicate[Product] {
-	return func(p Product) bool {
-		return p.Price <= maxPrice
-	}
+func ByMaxPrice(maxPrice float64) predicate.Named[Product] {
+	return predicate.Leaf("max_price", productPrice.Le(maxPrice), maxPrice).WithFormat("price <= %v").
+		WithDetail(priceDetail)
 }
 
 // ByMinPrice creates a predicate for minimum price
-func ByMinPrice(minPrice float64) Predicate[Product] {
-	return func(p Product) bool {
-		return p.Price >= minPrice
+func ByMinPrice(minPrice float64) predicate.Named[Product] {
+	return predicate.Leaf("min_price", productPrice.Ge(minPrice), minPrice).WithFormat("price >= %v").
+		WithDetail(priceDetail)
+}
+
+// priceDetail reports the price a leaf inspected when it is explained.
+func priceDetail(p Product) string {
+	return fmt.Sprintf("Price=%.2f", p.Price)
+}
+
+// MergePriceBounds is a simplifier rule that merges ByMinPrice, ByMaxPrice and
+// ByPriceRange operands of an AND into a single, tightest bound.
+func MergePriceBounds(a, b predicate.Named[Product]) (predicate.Named[Product], bool) {
+	lo1, hi1, ok1 := priceBounds(a.Node())
+	lo2, hi2, ok2 := priceBounds(b.Node())
+	if !ok1 || !ok2 {
+		return a, false
+	}
+	lo, hi := max(lo1, lo2), min(hi1, hi2)
+	switch {
+	case lo > hi:
+		return predicate.False[Product](), true
+	case math.IsInf(hi, 1):
+		return ByMinPrice(lo), true
+	case math.IsInf(lo, -1):
+		return ByMaxPrice(hi), true
+	}
+	return ByPriceRange(lo, hi), true
+}
+
+// priceBounds returns the price interval accepted by a price leaf. Leaves that
+// only share a name with the price constructors are not price leaves.
+func priceBounds(n predicate.Node) (lo, hi float64, ok bool) {
+	args := make([]float64, len(n.Args))
+	for i, arg := range n.Args {
+		if args[i], ok = arg.(float64); !ok {
+			return 0, 0, false
+		}
+	}
+	switch {
+	case n.Name == "min_price" && len(args) == 1:
+		return args[0], math.Inf(1), true
+	case n.Name == "max_price" && len(args) == 1:
+		return math.Inf(-1), args[0], true
+	case n.Name == "price_range" && len(args) == 2:
+		return args[0], args[1], true
 	}
+	return 0, 0, false
 }
 
 // DemoPredicatePattern shows how to use predicates to filter a collection of products
@@ -192,7 +212,7 @@ func DemoPredicatePattern() {
 
 	// Example 1: Simple filtering
 	fmt.Println("1. Filter by category:")
-	electronics := Filter(products, ByCategory("Electronics"))
+	electronics := predicate.Filter(products, ByCategory("Electronics").Test)
 	fmt.Printf("   Found %d electronics\n", len(electronics))
 	for _, p := range electronics {
 		fmt.Printf("   - %s", p.Name)
@@ -201,7 +221,7 @@ func DemoPredicatePattern() {
 
 	// Example 2: Filter by price range
 	fmt.Println("2. Filter by price range ($100-$400):")
-	midRange := Filter(products, ByPriceRange(100, 400))
+	midRange := predicate.Filter(products, ByPriceRange(100, 400).Test)
 	fmt.Printf("   Found %d products", len(midRange))
 	for _, p := range midRange {
 		fmt.Printf("   - %s: $%.2f\n", p.Name, p.Price)
@@ -210,7 +230,9 @@ func DemoPredicatePattern() {
 
 	// Example 3: Combining predicates with AND
 	fmt.Println("3. Combine predicates (Electronics AND InStock):")
-	availableElectronics := Filter(products, And(ByCategory("Electronics"), InStock()))
+	available := ByCategory("Electronics").And(InStock())
+	fmt.Printf("   Predicate: %s\n", available)
+	availableElectronics := predicate.Filter(products, available.Test)
 	fmt.Printf("   Found %d available electronics\n", len(availableElectronics))
 	for _, p := range availableElectronics {
 		fmt.Printf("   - %s", p.Name)
@@ -219,11 +241,8 @@ func DemoPredicatePattern() {
 
 	// Example 4: Complex combinations
 	fmt.Println("4. Complex combination (InStock AND Price<$300 AND Rating>=4.5):")
-	affordableQuality := Filter(products,
-		And(
-			And(InStock(), ByMaxPrice(300)),
-			ByMinRating(4.5),
-		),
+	affordableQuality := predicate.Filter(products,
+		InStock().And(ByMaxPrice(300)).And(ByMinRating(4.5)).Test,
 	)
 	fmt.Printf("   Found %d products", len(affordableQuality))
 	for _, p := range affordableQuality {
@@ -233,8 +252,8 @@ func DemoPredicatePattern() {
 
 	// Example 5: Using OR
 	fmt.Println("5. Using OR (Category=Furniture OR Price<$50):")
-	furnitureOrCheap := Filter(products,
-		Or(ByCategory("Furniture"), ByMaxPrice(50)),
+	furnitureOrCheap := predicate.Filter(products,
+		ByCategory("Furniture").Or(ByMaxPrice(50)).Test,
 	)
 	fmt.Printf("   Found %d products\n", len(furnitureOrCheap))
 	for _, p := range furnitureOrCheap {
@@ -244,7 +263,7 @@ func DemoPredicatePattern() {
 
 	// Example 6: Using NOT
 	fmt.Println("6. Using NOT (NOT InStock):")
-	outOfStock := Filter(products, Not(InStock()))
+	outOfStock := predicate.Filter(products, InStock().Not().Test)
 	fmt.Printf("   Found %d out-of-stock products\n", len(outOfStock))
 	for _, p := range outOfStock {
 		fmt.Printf("   - %s\n", p.Name)
@@ -253,7 +272,7 @@ func DemoPredicatePattern() {
 
 	// Example 7: Name search
 	fmt.Println("7. Search by name (contains 'key'):")
-	matchingName := Filter(products, ByNameContains("key"))
+	matchingName := predicate.Filter(products, ByNameContains("key").Test)
 	fmt.Printf("   Found %d products\n", len(matchingName))
 	for _, p := range matchingName {
 		fmt.Printf("   - %s\n", p.Name)
@@ -262,25 +281,25 @@ func DemoPredicatePattern() {
 
 	// Example 8: Using Any
 	fmt.Println("8. Check if any product is over $500:")
-	hasExpensive := Any(products, ByMinPrice(500))
+	hasExpensive := predicate.Any(products, ByMinPrice(500).Test)
 	fmt.Printf("   Has expensive products: %v\n", hasExpensive)
 	fmt.Println()
 
 	// Example 9: Using All
 	fmt.Println("9. Check if all products have rating >= 4.0:")
-	allHighRated := All(products, ByMinRating(4.0))
+	allHighRated := predicate.All(products, ByMinRating(4.0).Test)
 	fmt.Printf("   All products highly rated: %v\n", allHighRated)
 	fmt.Println()
 
 	// Example 10: Count
 	fmt.Println("10. Count products from TechCorp:")
-	techCorpCount := Count(products, BySupplier("TechCorp"))
+	techCorpCount := predicate.Count(products, BySupplier("TechCorp").Test)
 	fmt.Printf("    TechCorp products: %d\n", techCorpCount)
 	fmt.Println()
 
 	// Example 11: Find first
 	fmt.Println("11. Find first furniture item:")
-	if furniture, found := Find(products, ByCategory("Furniture")); found {
+	if furniture, found := predicate.Find(products, ByCategory("Furniture").Test); found {
 		fmt.Printf("    Found: %s\n", furniture.Name)
 	}
 	fmt.Println()
@@ -288,75 +307,122 @@ func DemoPredicatePattern() {
 	// Example 12: Complex real-world scenario
 	fmt.Println("12. Real-world scenario: Premium in-stock electronics")
 	fmt.Println("    (Electronics AND InStock AND Rating>=4.5 AND Price>=100)")
-	premiumElectronics := Filter(products,
-		And(
-			And(ByCategory("Electronics"), InStock()),
-			And(ByMinRating(4.5), ByMinPrice(100)),
-		),
+	premium := predicate.AllOf(
+		ByCategory("Electronics"),
+		InStock(),
+		ByMinRating(4.5),
+		ByMinPrice(100),
 	)
+	fmt.Printf("    Predicate: %s\n", premium)
+	premiumElectronics := predicate.Filter(products, premium.Test)
 	fmt.Printf("    Found %d premium electronics\n", len(premiumElectronics))
 	for _, p := range premiumElectronics {
 		fmt.Printf("    - %s: $%.2f (Rating: %.1f)\n", p.Name, p.Price, p.Rating)
 	}
-}
-
-// Generic predicate examples for different types
-
-// Integer predicates
-
-func IsEven(n int) bool {
-	return n%2 == 0
-}
-
-func IsOdd(n int) bool {
-	return n%2 != 0
-}
-
-func IsPositive(n int) bool {
-	return n > 0
-}
-
-func GreaterThan(threshold int) Predicate[int] {
-	return func(n int) bool {
-		return n > threshold
-	}
-}
+	fmt.Println()
 
-func LessThan(threshold int) Predicate[int] {
-	return func(n int) bool {
-		return n < threshold
+	// Example 13: Explain why an item was rejected
+	fmt.Println("13. Explain: why is Chair not a cheap, in-stock electronic?")
+	cheapElectronics := ByCategory("Electronics").And(InStock()).And(ByMaxPrice(300))
+	chair := products[3]
+	explanation := cheapElectronics.Explain(chair)
+	fmt.Printf("    %s\n", explanation.Reason())
+	for _, line := range strings.Split(explanation.String(), "\n") {
+		fmt.Printf("    %s\n", line)
 	}
-}
+	fmt.Println()
 
-func Between(min, max int) Predicate[int] {
-	return func(n int) bool {
-		return n >= min && n <= max
+	// Example 14: Let a hot filter learn its cheapest evaluation order
+	fmt.Println("14. Adaptive: in-stock products whose name contains \"o\"")
+	adaptive := predicate.Adaptive(ByNameContains("o").And(InStock()))
+	fmt.Printf("    Predicate: %s\n", adaptive)
+	matches := 0
+	for range 1000 {
+		matches += predicate.Count(products, adaptive.Test)
 	}
-}
+	fmt.Printf("    Matches per pass: %d\n", matches/1000)
+	fmt.Println()
 
-// String predicates
+	// Example 15: Simplify a generated filter
+	fmt.Println("15. Simplify a filter generated by the UI")
+	generated := predicate.AllOf(
+		ByMinPrice(100),
+		InStock().Not().Not(),
+		ByCategory("Electronics").Or(predicate.True[Product]()),
+		ByMaxPrice(300),
+		InStock(),
+	)
+	simplified := predicate.Simplify(generated, MergePriceBounds)
+	fmt.Printf("    Generated:  %s\n", generated)
+	fmt.Printf("    Simplified: %s\n", simplified)
+	fmt.Printf("    Matches: %d before, %d after\n",
+		predicate.Count(products, generated.Test), predicate.Count(products, simplified.Test))
+	cheapOrFurniture := ByCategory("Furniture").Or(ByMaxPrice(50)).And(InStock())
+	fmt.Printf("    DNF of %s:\n", cheapOrFurniture)
+	fmt.Printf("      %s\n", predicate.DNF(cheapOrFurniture))
+	fmt.Println()
 
-func HasPrefix(prefix string) Predicate[string] {
-	return func(s string) bool {
-		return strings.HasPrefix(s, prefix)
+	// Example 16: Generated constructors and builder
+	fmt.Println("16. Generated builder: in-stock Electronics or Accessories under $100")
+	generatedFilter := NewProductPredicateBuilder().
+		WithCategoryIn("Electronics", "Accessories").
+		WithInStockEq(true).
+		WithPriceLe(100).
+		Build()
+	for _, p := range predicate.Filter(products, generatedFilter) {
+		fmt.Printf("    - %s: $%.2f\n", p.Name, p.Price)
 	}
-}
+	fmt.Println()
 
-func HasSuffix(suffix string) Predicate[string] {
-	return func(s string) bool {
-		return strings.HasSuffix(s, suffix)
+	// Example 17: Split and group instead of filtering twice
+	fmt.Println("17. Partition by stock and group by category:")
+	stocked, soldOut := predicate.Partition(products, InStock().Test)
+	fmt.Printf("    In stock: %d, out of stock: %d\n", len(stocked), len(soldOut))
+	byCategory := predicate.GroupBy(products, func(p Product) string { return p.Category })
+	for _, category := range slices.Sorted(maps.Keys(byCategory)) {
+		fmt.Printf("    %s: %d product(s)\n", category, len(byCategory[category]))
 	}
-}
+	fmt.Println()
+
+	// Example 18: Cache an expensive check across several passes
+	fmt.Println("18. Memoize an expensive check by product ID:")
+	premiumScore := predicate.Memoize(func(p Product) bool {
+		return p.Rating*100-p.Price/10 > 400 // stands in for a costly scoring model
+	}, func(p Product) int { return p.ID }, predicate.WithCapacity(100), predicate.WithTTL(time.Minute))
+	fmt.Printf("    Premium: %d, any: %v, all: %v\n",
+		predicate.Count(products, premiumScore.Test),
+		predicate.Any(products, premiumScore.Test),
+		predicate.All(products, premiumScore.Test))
+	stats := premiumScore.Stats()
+	fmt.Printf("    Cache: %d hits, %d misses (%.0f%% hit rate)\n", stats.Hits, stats.Misses, stats.HitRate()*100)
+	fmt.Println()
 
-func Contains(substring string) Predicate[string] {
-	return func(s string) bool {
-		return strings.Contains(s, substring)
+	// Example 19: Forgiving name search
+	fmt.Println("19. Search names with typos and partial words:")
+	for _, search := range []predicate.Named[Product]{ByNameFuzzy("keybaord", 1), ByNameWord("MON"), ByNameContains("OUS")} {
+		fmt.Printf("    %s:", search)
+		for _, p := range predicate.Filter(products, search.Test) {
+			fmt.Printf(" %s", p.Name)
+		}
+		fmt.Println()
 	}
-}
+	fmt.Println()
 
-func LongerThan(length int) Predicate[string] {
-	return func(s string) bool {
-		return len(s) > length
+	// Example 20: Answer tag queries from an inverted index
+	fmt.Println("20. Tag queries through an inverted index:")
+	tagIndex := NewTagIndex(products)
+	fmt.Printf("    accessory: %d, office: %d\n", tagIndex.Count("accessory"), tagIndex.Count("office"))
+	for _, query := range []predicate.Named[Product]{
+		HasAnyTag("wireless", "ergonomic"),
+		HasAllTags("office", "wooden"),
+		HasTag("accessory").And(InStock()),
+		HasNoTags("office", "accessory"),
+	} {
+		fmt.Printf("    %s:", query)
+		for _, p := range tagIndex.Find(query) {
+			fmt.Printf(" %s", p.Name)
+		}
+		fmt.Println()
 	}
 }
 
@@ -368,29 +434,29 @@ func DemoGenericPredicates() {
 	numbers := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 
 	fmt.Println("1. Filter even numbers:")
-	evenNumbers := Filter(numbers, Predicate[int](IsEven))
+	evenNumbers := predicate.Filter(numbers, predicate.IsEven)
 	fmt.Printf("   %v\n", evenNumbers)
 
 	fmt.Println("2. Filter numbers > 5:")
-	greaterThan5 := Filter(numbers, GreaterThan(5))
+	greaterThan5 := predicate.Filter(numbers, predicate.GreaterThan(5))
 	fmt.Printf("   %v\n", greaterThan5)
 
 	fmt.Println("3. Filter numbers between 3 and 7:")
-	between3and7 := Filter(numbers, Between(3, 7))
+	between3and7 := predicate.Filter(numbers, predicate.Between(3, 7))
 	fmt.Printf("   %v\n", between3and7)
 
 	// String filtering
 	words := []string{"apple", "banana", "cherry", "date", "elderberry", "fig", "grape"}
 
 	fmt.Println("4. Filter words starting with 'b':")
-	startsWithB := Filter(words, HasPrefix("b"))
+	startsWithB := predicate.Filter(words, predicate.HasPrefix("b"))
 	fmt.Printf("   %v\n\n", startsWithB)
 
 	fmt.Println("5. Filter words longer than 5 characters:")
-	longWords := Filter(words, LongerThan(5))
+	longWords := predicate.Filter(words, predicate.LongerThan(5))
 	fmt.Printf("   %v\n\n", longWords)
 
 	fmt.Println("6. Complex: Even numbers greater than 5:")
-	evenAndGreater := Filter(numbers, And(Predicate[int](IsEven), GreaterThan(5)))
+	evenAndGreater := predicate.Filter(numbers, predicate.And(predicate.IsEven, predicate.GreaterThan(5)))
 	fmt.Printf("   %v\n", evenAndGreater)
 }
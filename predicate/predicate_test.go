@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+
+func TestMergePriceBounds(t *testing.T) {
+	cheap := func(p Product) bool { return p.Price < 50 }
+	tests := []struct {
+		name string
+		pred predicate.Named[Product]
+		want string
+	}{
+		{"min and max", predicate.AllOf(ByMinPrice(100), InStock(), ByMaxPrice(300)), "(price BETWEEN 100 AND 300 AND in_stock)"},
+		{"two minimums", ByMinPrice(100).And(ByMinPrice(200)), "price >= 200"},
+		{"two maximums", ByMaxPrice(100).And(ByMaxPrice(200)), "price <= 100"},
+		{"range narrowed", ByPriceRange(50, 500).And(ByMaxPrice(300)), "price BETWEEN 50 AND 300"},
+		{"empty range", ByMinPrice(400).And(ByMaxPrice(300)), "FALSE"},
+		{"other leaves untouched", ByCategory("Electronics").And(ByMinRating(4)), `(category = "Electronics" AND rating >= 4)`},
+		{"foreign price leaves untouched", predicate.Leaf("min_price", cheap, "cheap").And(predicate.Leaf("max_price", cheap)).And(ByMaxPrice(300)), `(min_price("cheap") AND max_price AND price <= 300)`},
+	}
+
+	products := []Product{
+		{Name: "Cable", Category: "Electronics", Price: 20, InStock: true, Rating: 4.1},
+		{Name: "Monitor", Category: "Electronics", Price: 250, InStock: true, Rating: 4.6},
+		{Name: "Desk", Category: "Furniture", Price: 350, InStock: false, Rating: 3.9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := predicate.Simplify(tt.pred, MergePriceBounds)
+			if got.String() != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			for _, p := range products {
+				if got.Test(p) != tt.pred.Test(p) {
+					t.Errorf("%s disagrees with %s on %s", got, tt.pred, p.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestNameSearch(t *testing.T) {
+	products := []Product{{Name: "Mechanical Keyboard"}, {Name: "Keyring"}, {Name: "ΟΔΟΣ Lamp"}, {Name: "4K Monitor"}}
+	tests := []struct {
+		name string
+		pred predicate.Named[Product]
+		want []string
+	}{
+		{"contains folds case", ByNameContains("KEY"), []string{"Mechanical Keyboard", "Keyring"}},
+		{"contains folds unicode", ByNameContains("οδος"), []string{"ΟΔΟΣ Lamp"}},
+		{"word prefix", ByNameWord("mon"), []string{"4K Monitor"}},
+		{"word prefix skips inner", ByNameWord("board"), nil},
+		{"fuzzy transposition", ByNameFuzzy("keybaord", 1), []string{"Mechanical Keyboard"}},
+		{"fuzzy exact only", ByNameFuzzy("keybaord", 0), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, p := range predicate.Filter(products, tt.pred.Test) {
+				got = append(got, p.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGeneratedBuilderRejectsCombinatorSwitch(t *testing.T) {
+	products := []Product{{Name: "Mouse", Price: 25}, {Name: "Laptop", Price: 999}, {Name: "Cable", Price: 5}}
+
+	b := NewProductPredicateBuilder().WithPriceGe(10).WithPriceLe(100).UseOR()
+	if err := b.Err(); !errors.Is(err, predicate.ErrMixedCombinators) {
+		t.Errorf("expected ErrMixedCombinators, got %v", err)
+	}
+	// The switch was ignored, so the conditions still mean 10 <= price <= 100
+	var names []string
+	for _, p := range predicate.Filter(products, b.Build()) {
+		names = append(names, p.Name)
+	}
+	if !slices.Equal(names, []string{"Mouse"}) {
+		t.Errorf("expected [Mouse], got %v", names)
+	}
+
+	// Choosing OR before adding conditions stays allowed
+	if b := NewProductPredicateBuilder().UseOR().WithPriceLe(10).WithPriceGe(500); b.Err() != nil {
+		t.Errorf("unexpected error %v", b.Err())
+	} else if got := predicate.Count(products, b.Build()); got != 2 {
+		t.Errorf("expected 2 matches, got %d", got)
+	}
+}
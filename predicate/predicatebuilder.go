@@ -1,19 +1,30 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+	"github.com/vdntruong/gopatterns/pkg/predicate/spec"
+)
 
 // PredicateBuilder provides a fluent interface for building complex predicates
 // This combines the Predicate pattern with the Builder pattern
 
 // Process represents a system process
 type Process struct {
-	ID       int
-	Title    string
-	Status   string
-	Priority int
-	Owner    string
-	CPUUsage float64
-	Memory   int64
+	ID        int
+	Title     string
+	Status    string
+	Priority  int
+	Owner     string
+	CPUUsage  float64
+	Memory    int64
+	StartedAt time.Time
 }
 
 func (p *Process) String() string {
@@ -24,88 +35,253 @@ func (p *Process) String() string {
 // ProcessPredicate is a function that tests a Process
 type ProcessPredicate func(*Process) bool
 
+// ErrMixedCombinators is reported (wrapped) by Err for a builder whose
+// combinator would otherwise have to be guessed: UseAND or UseOR on a builder
+// with groups or inside a group, a group added after UseAND or UseOR, or a
+// switch of combinator after conditions were added. It is the library's
+// sentinel, shared with the generated ProductPredicateBuilder.
+var ErrMixedCombinators = predicate.ErrMixedCombinators
+
 // ProcessPredicateBuilder builds complex process predicates
+//
+// Each condition is recorded as a specification leaf, so the builder's
+// configuration can also be exported with Specification. Conditions are
+// combined either by the builder-wide UseAND/UseOR switch or, for mixed
+// expressions such as running AND (owner = user1 OR owner = user2), by
+// nesting AllOf, AnyOf and Not groups; the two styles cannot be mixed. A call
+// that would mix them is ignored and recorded, and Err reports the first one,
+// so a builder assembled from user input never panics.
 type ProcessPredicateBuilder struct {
-	predicates []ProcessPredicate
-	combineOp  string // "AND" or "OR"
+	predicates []ProcessSpecification
+	combineOp  string          // "AND" or "OR"
+	clock      predicate.Clock // anchors relative time windows
+	switched   bool            // UseAND or UseOR was called
+	grouped    bool            // a group was added
+	group      bool            // the builder collects the conditions of a group
+	err        error           // first misuse, see Err
 }
 
 // NewProcessPredicateBuilder creates a new predicate builder
 func NewProcessPredicateBuilder() *ProcessPredicateBuilder {
 	return &ProcessPredicateBuilder{
-		predicates: []ProcessPredicate{},
+		predicates: []ProcessSpecification{},
 		combineOp:  "AND",
+		clock:      time.Now,
 	}
 }
 
+// WithClock sets the clock that WithStartedWithin reads (time.Now by default)
+func (b *ProcessPredicateBuilder) WithClock(clock predicate.Clock) *ProcessPredicateBuilder {
+	if clock != nil {
+		b.clock = clock
+	}
+	return b
+}
+
 // WithTitle adds a title filter
 func (b *ProcessPredicateBuilder) WithTitle(title string) *ProcessPredicateBuilder {
-	b.predicates = append(b.predicates, ByTitle(title))
+	b.predicates = append(b.predicates, titleLeaf(title))
 	return b
 }
 
 // WithID adds an ID filter
 func (b *ProcessPredicateBuilder) WithID(id int) *ProcessPredicateBuilder {
-	b.predicates = append(b.predicates, ByID(id))
+	b.predicates = append(b.predicates, idLeaf(id))
 	return b
 }
 
 // WithStatus adds a status filter
 func (b *ProcessPredicateBuilder) WithStatus(status string) *ProcessPredicateBuilder {
-	b.predicates = append(b.predicates, ByStatus(status))
+	b.predicates = append(b.predicates, statusLeaf(status))
 	return b
 }
 
 // WithMinPriority adds a minimum priority filter
 func (b *ProcessPredicateBuilder) WithMinPriority(priority int) *ProcessPredicateBuilder {
-	b.predicates = append(b.predicates, ByMinPriority(priority))
+	b.predicates = append(b.predicates, minPriorityLeaf(priority))
 	return b
 }
 
 // WithOwner adds an owner filter
 func (b *ProcessPredicateBuilder) WithOwner(owner string) *ProcessPredicateBuilder {
-	b.predicates = append(b.predicates, ByOwner(owner))
+	b.predicates = append(b.predicates, ownerLeaf(owner))
 	return b
 }
 
 // WithMaxCPU adds a maximum CPU usage filter
 func (b *ProcessPredicateBuilder) WithMaxCPU(maxCPU float64) *ProcessPredicateBuilder {
-	b.predicates = append(b.predicates, ByMaxCPU(maxCPU))
+	b.predicates = append(b.predicates, maxCPULeaf(maxCPU))
 	return b
 }
 
 // WithMinMemory adds a minimum memory filter
 func (b *ProcessPredicateBuilder) WithMinMemory(minMemory int64) *ProcessPredicateBuilder {
-	b.predicates = append(b.predicates, ByMinMemory(minMemory))
+	b.predicates = append(b.predicates, minMemoryLeaf(minMemory))
 	return b
 }
 
-// UseAND sets the combinator to AND (default)
-func (b *ProcessPredicateBuilder) UseAND() *ProcessPredicateBuilder {
-	b.combineOp = "AND"
+// WithStartedWithin adds a filter for processes started within the last d.
+// The window is anchored when the condition is added, as started_at > now-d,
+// so the built predicate, its SQL and its saved JSON all select the same
+// processes no matter when they run.
+func (b *ProcessPredicateBuilder) WithStartedWithin(d time.Duration) *ProcessPredicateBuilder {
+	b.predicates = append(b.predicates, startedAfterLeaf(b.clock().Add(-d)))
 	return b
 }
 
-// UseOR sets the combinator to OR
+// UseAND sets the combinator to AND (default). It is ignored, and Err
+// reports ErrMixedCombinators, when the builder has groups or is a group, or
+// when it would change how already added conditions combine.
+func (b *ProcessPredicateBuilder) UseAND() *ProcessPredicateBuilder {
+	return b.use("AND")
+}
+
+// UseOR sets the combinator to OR. It is rejected like UseAND.
 func (b *ProcessPredicateBuilder) UseOR() *ProcessPredicateBuilder {
-	b.combineOp = "OR"
+	return b.use("OR")
+}
+
+func (b *ProcessPredicateBuilder) use(op string) *ProcessPredicateBuilder {
+	switch {
+	case b.group:
+		return b.fail(fmt.Errorf("%w: Use%s inside a group, which combines its conditions itself", ErrMixedCombinators, op))
+	case b.grouped:
+		return b.fail(fmt.Errorf("%w: Use%s on a builder with groups; wrap the conditions in AllOf or AnyOf instead", ErrMixedCombinators, op))
+	case len(b.predicates) > 0 && op != b.combineOp:
+		return b.fail(fmt.Errorf("%w: Use%s after %d condition(s) were added with %s", ErrMixedCombinators, op, len(b.predicates), b.combineOp))
+	}
+	b.combineOp = op
+	b.switched = true
+	return b
+}
+
+// fail records err unless an earlier misuse was recorded.
+func (b *ProcessPredicateBuilder) fail(err error) *ProcessPredicateBuilder {
+	if b.err == nil {
+		b.err = err
+	}
 	return b
 }
 
-// Build creates the final predicate
+// Err returns the first misuse of the builder, wrapping ErrMixedCombinators,
+// or nil. The misused calls were ignored, so Build and Specification still
+// describe the remaining conditions, but they are not what the caller asked
+// for; check Err before using them, or use BuildE.
+func (b *ProcessPredicateBuilder) Err() error {
+	return b.err
+}
+
+// AllOf adds a group whose conditions, added by build, must all hold, e.g.
+//
+//	NewProcessPredicateBuilder().
+//		AnyOf(func(g *ProcessPredicateBuilder) {
+//			g.WithOwner("user1").
+//				AllOf(func(g *ProcessPredicateBuilder) { g.WithOwner("user2").WithMinPriority(5) })
+//		})
+//
+// Groups can be nested. After UseAND or UseOR the group is ignored and Err
+// reports ErrMixedCombinators.
+func (b *ProcessPredicateBuilder) AllOf(build func(g *ProcessPredicateBuilder)) *ProcessPredicateBuilder {
+	return b.addGroup("AND", false, build)
+}
+
+// AnyOf adds a group of which at least one condition, added by build, must
+// hold:
+//
+//	NewProcessPredicateBuilder().
+//		WithStatus("running").
+//		AnyOf(func(g *ProcessPredicateBuilder) { g.WithOwner("user1").WithOwner("user2") })
+//
+// It is rejected like AllOf after UseAND or UseOR.
+func (b *ProcessPredicateBuilder) AnyOf(build func(g *ProcessPredicateBuilder)) *ProcessPredicateBuilder {
+	return b.addGroup("OR", false, build)
+}
+
+// Not adds a group that holds when its conditions, added by build and
+// combined with AND, do not all hold. Nest AnyOf inside it to exclude
+// processes matching any of several conditions. It is rejected like AllOf
+// after UseAND or UseOR.
+func (b *ProcessPredicateBuilder) Not(build func(g *ProcessPredicateBuilder)) *ProcessPredicateBuilder {
+	return b.addGroup("AND", true, build)
+}
+
+// addGroup collects the conditions of a group in a nested builder and adds
+// them as a single operand. A group with one condition adds that condition
+// directly rather than an AND or OR node of one operand. A misuse inside the
+// group is recorded on b.
+func (b *ProcessPredicateBuilder) addGroup(op string, negate bool, build func(g *ProcessPredicateBuilder)) *ProcessPredicateBuilder {
+	if b.switched {
+		return b.fail(fmt.Errorf("%w: group added after Use%s; use groups for every level instead", ErrMixedCombinators, b.combineOp))
+	}
+	g := &ProcessPredicateBuilder{
+		predicates: []ProcessSpecification{},
+		combineOp:  op,
+		clock:      b.clock,
+		group:      true,
+	}
+	build(g)
+	if g.err != nil {
+		b.fail(g.err)
+	}
+
+	group := g.combined()
+	if len(g.predicates) == 1 {
+		group = g.predicates[0]
+	}
+	if negate {
+		group = group.Not()
+	}
+	b.predicates = append(b.predicates, group)
+	b.grouped = true
+	return b
+}
+
+// Clone returns an independent copy of the builder. Conditions added to
+// either builder afterwards do not affect the other, so a base query can be
+// extended in several directions:
+//
+//	running := NewProcessPredicateBuilder().WithStatus("running")
+//	mine := running.Clone().WithOwner("user1")
+//	busy := running.Clone().WithMinPriority(5)
+func (b *ProcessPredicateBuilder) Clone() *ProcessPredicateBuilder {
+	clone := *b
+	clone.predicates = slices.Clone(b.predicates)
+	return &clone
+}
+
+// Fork returns a clone of the builder extended by extend, leaving the
+// builder itself unchanged:
+//
+//	mine := running.Fork(func(b *ProcessPredicateBuilder) { b.WithOwner("user1") })
+//
+// A builder is not safe for concurrent modification, but Clone, Fork, Build
+// and Specification only read it, so once a base builder is complete it can
+// be shared and forked from several goroutines.
+func (b *ProcessPredicateBuilder) Fork(extend func(b *ProcessPredicateBuilder)) *ProcessPredicateBuilder {
+	fork := b.Clone()
+	extend(fork)
+	return fork
+}
+
+// Build creates the final predicate. The predicate is a snapshot of the
+// builder's conditions: adding conditions or changing the builder afterwards
+// does not affect predicates already built. It is an opaque function, so
+// ProcessManager.Find scans every process with it; ProcessManager.FindBuilder
+// lets declared indexes answer the query instead.
 func (b *ProcessPredicateBuilder) Build() ProcessPredicate {
-	if len(b.predicates) == 0 {
+	predicates := slices.Clone(b.predicates)
+	if len(predicates) == 0 {
 		return func(*Process) bool { return true }
 	}
 
-	if len(b.predicates) == 1 {
-		return b.predicates[0]
+	if len(predicates) == 1 {
+		return predicates[0].IsSatisfiedBy
 	}
 
 	if b.combineOp == "OR" {
 		return func(p *Process) bool {
-			for _, pred := range b.predicates {
-				if pred(p) {
+			for _, pred := range predicates {
+				if pred.IsSatisfiedBy(p) {
 					return true
 				}
 			}
@@ -115,8 +291,8 @@ func (b *ProcessPredicateBuilder) Build() ProcessPredicate {
 
 	// Default: AND
 	return func(p *Process) bool {
-		for _, pred := range b.predicates {
-			if !pred(p) {
+		for _, pred := range predicates {
+			if !pred.IsSatisfiedBy(p) {
 				return false
 			}
 		}
@@ -124,88 +300,90 @@ func (b *ProcessPredicateBuilder) Build() ProcessPredicate {
 	}
 }
 
+// BuildE is like Build but returns Err instead of a predicate when the
+// builder was misused.
+func (b *ProcessPredicateBuilder) BuildE() (ProcessPredicate, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.Build(), nil
+}
+
+// Specification returns the builder's conditions as a specification tree,
+// e.g. to save it with MarshalSpecification. Like Build, it is a snapshot. An
+// empty builder matches every process and has no serializable form.
+func (b *ProcessPredicateBuilder) Specification() ProcessSpecification {
+	if len(b.predicates) == 0 {
+		return NewSpecification(func(*Process) bool { return true })
+	}
+	return b.combined()
+}
+
+// combined joins the conditions with the builder's combinator. Unlike
+// Specification it keeps the identity of an empty list, so an empty AnyOf
+// group matches nothing.
+func (b *ProcessPredicateBuilder) combined() ProcessSpecification {
+	if b.combineOp == "OR" {
+		return spec.AnyOf(b.predicates...)
+	}
+	return spec.AllOf(b.predicates...)
+}
+
 // Individual predicate constructors for Process
 
+// Field accessors shared by the constructors below.
+var (
+	processID       = predicate.Field(func(p *Process) int { return p.ID })
+	processTitle    = predicate.Field(func(p *Process) string { return p.Title })
+	processStatus   = predicate.Field(func(p *Process) string { return p.Status })
+	processOwner    = predicate.Field(func(p *Process) string { return p.Owner })
+	processPriority = predicate.Field(func(p *Process) int { return p.Priority })
+	processCPU      = predicate.Field(func(p *Process) float64 { return p.CPUUsage })
+	processMemory   = predicate.Field(func(p *Process) int64 { return p.Memory })
+	processStarted  = func(p *Process) time.Time { return p.StartedAt }
+)
+
 func ByTitle(title string) ProcessPredicate {
-	return func(p *Process) bool {
-		return p.Title == title
-	}
+	return ProcessPredicate(processTitle.Eq(title))
 }
 
 func ByID(id int) ProcessPredicate {
-	return func(p *Process) bool {
-		return p.ID == id
-	}
+	return ProcessPredicate(processID.Eq(id))
 }
 
 func ByStatus(status string) ProcessPredicate {
-	return func(p *Process) bool {
-		return p.Status == status
-	}
+	return ProcessPredicate(processStatus.Eq(status))
 }
 
 func ByMinPriority(minPriority int) ProcessPredicate {
-	return func(p *Process) bool {
-		return p.Priority >= minPriority
-	}
+	return ProcessPredicate(processPriority.Ge(minPriority))
 }
 
 func ByOwner(owner string) ProcessPredicate {
-	return func(p *Process) bool {
-		return p.Owner == owner
-	}
+	return ProcessPredicate(processOwner.Eq(owner))
 }
 
 func ByMaxCPU(maxCPU float64) ProcessPredicate {
-	return func(p *Process) bool {
-		return p.CPUUsage <= maxCPU
-	}
+	return ProcessPredicate(processCPU.Le(maxCPU))
 }
 
 func ByMinMemory(minMemory int64) ProcessPredicate {
-	return func(p *Process) bool {
-		return p.Memory >= minMemory
-	}
-}
-
-// ProcessManager manages a collection of processes
-type ProcessManager struct {
-	processes []*Process
-}
-
-// CreateProcessManager creates a new process manager with sample data
-func CreateProcessManager() *ProcessManager {
-	return &ProcessManager{
-		processes: []*Process{
-			{ID: 1, Title: "Go", Status: "running", Priority: 5, Owner: "user1", CPUUsage: 25.5, Memory: 1024},
-			{ID: 2, Title: "Python", Status: "running", Priority: 3, Owner: "user2", CPUUsage: 15.2, Memory: 2048},
-			{ID: 3, Title: "C++", Status: "stopped", Priority: 7, Owner: "user1", CPUUsage: 0.0, Memory: 512},
-			{ID: 4, Title: "Java", Status: "running", Priority: 4, Owner: "user3", CPUUsage: 45.8, Memory: 4096},
-			{ID: 5, Title: "Rust", Status: "running", Priority: 6, Owner: "user1", CPUUsage: 10.3, Memory: 1536},
-			{ID: 6, Title: "Node", Status: "stopped", Priority: 2, Owner: "user2", CPUUsage: 0.0, Memory: 768},
-		},
-	}
+	return ProcessPredicate(processMemory.Ge(minMemory))
 }
 
-// Find filters processes using a predicate
-func (pm *ProcessManager) Find(predicate ProcessPredicate) []*Process {
-	var result []*Process
-	for _, p := range pm.processes {
-		if predicate(p) {
-			result = append(result, p)
-		}
-	}
-	return result
+func ByStartedAfter(t time.Time) ProcessPredicate {
+	return ProcessPredicate(predicate.On(processStarted, predicate.After(t)))
 }
 
-// GetAll returns all processes
-func (pm *ProcessManager) GetAll() []*Process {
-	return pm.processes
+// ByStartedWithin matches processes started within the last d. Unlike the
+// builder's WithStartedWithin, the window slides with the clock on every call.
+func ByStartedWithin(d time.Duration, opts ...predicate.TimeOption) ProcessPredicate {
+	return ProcessPredicate(predicate.On(processStarted, predicate.WithinLast(d, opts...)))
 }
 
 // Demo function showing predicate builder usage
 func DemoPredicateBuilder() {
-	fmt.Println("\n=== Predicate Builder Pattern Examples ===\n")
+	fmt.Print("\n=== Predicate Builder Pattern Examples ===\n\n")
 
 	pm := CreateProcessManager()
 
@@ -311,21 +489,129 @@ func DemoPredicateBuilder() {
 	for _, p := range result8 {
 		fmt.Printf("   - %s\n", p)
 	}
+	fmt.Println()
+
+	// Example 9: Let indexes narrow the search
+	fmt.Println("9. Find running processes of user1 through the status and owner indexes:")
+	for _, column := range []string{"status", "owner", "priority"} {
+		if err := pm.CreateIndex(column); err != nil {
+			fmt.Printf("   Error: %v\n", err)
+		}
+	}
+	query9 := NewProcessPredicateBuilder().
+		WithStatus("running").
+		WithOwner("user1")
+	result9, err := pm.FindBuilder(query9)
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+	}
+	fmt.Printf("   Found %d process(es)\n", len(result9))
+	for _, p := range result9 {
+		fmt.Printf("   - %s\n", p)
+	}
+	fmt.Println()
+
+	// Example 10: Group running processes by owner
+	fmt.Println("10. Running processes grouped by owner:")
+	byOwner := predicate.GroupBy(pm.FindSpecification(RunningSpecification()), func(p *Process) string { return p.Owner })
+	for _, owner := range slices.Sorted(maps.Keys(byOwner)) {
+		titles := make([]string, len(byOwner[owner]))
+		for i, p := range byOwner[owner] {
+			titles[i] = p.Title
+		}
+		fmt.Printf("   %s: %s\n", owner, strings.Join(titles, ", "))
+	}
+	fmt.Println()
+
+	// Example 11: Relative time windows with a fixed clock
+	fmt.Printf("11. Find processes started within the last 3 hours (as of %s):\n", sampleTime.Format(time.Kitchen))
+	pred11 := NewProcessPredicateBuilder().
+		WithClock(func() time.Time { return sampleTime }).
+		WithStartedWithin(3 * time.Hour).
+		Build()
+	for _, p := range pm.Find(pred11) {
+		fmt.Printf("   - %s (started %s ago)\n", p.Title, sampleTime.Sub(p.StartedAt))
+	}
+	fmt.Println()
+
+	// Example 12: Nested groups
+	fmt.Println("12. Find running processes of user1 or user2 that are not idle:")
+	builder12 := NewProcessPredicateBuilder().
+		WithStatus("running").
+		AnyOf(func(g *ProcessPredicateBuilder) {
+			g.WithOwner("user1").WithOwner("user2")
+		}).
+		Not(func(g *ProcessPredicateBuilder) {
+			g.WithMaxCPU(12)
+		})
+	where, _, err := ToSQL(builder12.Specification(), QuestionDialect)
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+	}
+	fmt.Printf("   WHERE %s\n", where)
+	result12, err := pm.FindBuilder(builder12)
+	if err != nil {
+		fmt.Printf("   Error: %v\n", err)
+	}
+	for _, p := range result12 {
+		fmt.Printf("   - %s (CPU: %.1f%%)\n", p, p.CPUUsage)
+	}
+	fmt.Println()
+
+	// Example 13: Extend one base query in several directions
+	fmt.Println("13. Fork a base query for each owner:")
+	running := NewProcessPredicateBuilder().WithStatus("running")
+	for _, owner := range []string{"user1", "user2"} {
+		fork := running.Fork(func(b *ProcessPredicateBuilder) { b.WithOwner(owner) })
+		fmt.Printf("   %s: %d running process(es)\n", owner, len(pm.Find(fork.Build())))
+	}
+	fmt.Printf("   base: %d running process(es)\n", len(pm.Find(running.Build())))
+	fmt.Println()
+
+	// Example 14: Change processes through the manager
+	fmt.Println("14. Upsert, update and remove processes, watching the version:")
+	before := pm.Version()
+	pm.Upsert(Process{ID: 7, Title: "Zig", Status: "running", Priority: 4, Owner: "user4", StartedAt: sampleTime})
+	pm.Update(6, func(p *Process) { p.Status = "running" })
+	pm.Remove(3)
+	for _, p := range pm.Find(ByStatus("running")) {
+		p.Status = "paused" // a copy: the manager is unaffected
+	}
+	fmt.Printf("   %d running process(es), version %d -> %d\n", len(pm.Find(ByStatus("running"))), before, pm.Version())
+	fmt.Println()
+
+	// Example 15: Watch a filter instead of polling it
+	fmt.Println("15. Subscribe to running processes above 40% CPU:")
+	ctx, cancel := context.WithCancel(context.Background())
+	busy := RunningSpecification().And(NewSpecification(func(p *Process) bool { return p.CPUUsage > 40 }))
+	sub := pm.Subscribe(ctx, busy, WithCurrentMatches(), WithBuffer(16))
+	pm.Update(2, func(p *Process) { p.CPUUsage = 55 })
+	pm.Update(2, func(p *Process) { p.CPUUsage = 70 })
+	pm.Update(4, func(p *Process) { p.Status = "stopped" })
+	cancel()
+	for event := range sub.Events() {
+		fmt.Printf("   v%d %-7s %s (CPU: %.1f%%)\n", event.Version, event.Kind, event.Process.Title, event.Process.CPUUsage)
+	}
+	fmt.Printf("   subscription ended: %v\n", sub.Err())
 }
 
 // Advanced: Specification pattern (similar to predicate but with additional methods)
 
-// ProcessSpecification is an interface for process specifications
-type ProcessSpecification interface {
-	IsSatisfiedBy(*Process) bool
-	And(ProcessSpecification) ProcessSpecification
-	Or(ProcessSpecification) ProcessSpecification
-	Not() ProcessSpecification
-}
+// ProcessSpecification is a specification for processes. The generic
+// spec package provides the AND, OR and NOT nodes; this file only adds
+// process-specific leaves.
+type ProcessSpecification = spec.Specification[*Process]
+
+type (
+	andSpecification = spec.AndNode[*Process]
+	orSpecification  = spec.OrNode[*Process]
+	notSpecification = spec.NotNode[*Process]
+)
 
 // baseSpecification implements ProcessSpecification
 type baseSpecification struct {
 	predicate ProcessPredicate
+	condition *sqlCondition // nil when the predicate has no SQL equivalent
 }
 
 func (s *baseSpecification) IsSatisfiedBy(p *Process) bool {
@@ -333,106 +619,81 @@ func (s *baseSpecification) IsSatisfiedBy(p *Process) bool {
 }
 
 func (s *baseSpecification) And(other ProcessSpecification) ProcessSpecification {
-	return &andSpecification{s, other}
+	return spec.And[*Process](s, other)
 }
 
 func (s *baseSpecification) Or(other ProcessSpecification) ProcessSpecification {
-	return &orSpecification{s, other}
+	return spec.Or[*Process](s, other)
 }
 
 func (s *baseSpecification) Not() ProcessSpecification {
-	return &notSpecification{s}
-}
-
-type andSpecification struct {
-	left, right ProcessSpecification
-}
-
-func (s *andSpecification) IsSatisfiedBy(p *Process) bool {
-	return s.left.IsSatisfiedBy(p) && s.right.IsSatisfiedBy(p)
-}
-
-func (s *andSpecification) And(other ProcessSpecification) ProcessSpecification {
-	return &andSpecification{s, other}
-}
-
-func (s *andSpecification) Or(other ProcessSpecification) ProcessSpecification {
-	return &orSpecification{s, other}
+	return spec.Not[*Process](s)
 }
 
-func (s *andSpecification) Not() ProcessSpecification {
-	return &notSpecification{s}
-}
+// Specification constructors
 
-type orSpecification struct {
-	left, right ProcessSpecification
+// NewSpecification wraps an arbitrary predicate. Such a specification works
+// in memory but cannot be translated to SQL.
+func NewSpecification(predicate ProcessPredicate) ProcessSpecification {
+	return &baseSpecification{predicate: predicate}
 }
 
-func (s *orSpecification) IsSatisfiedBy(p *Process) bool {
-	return s.left.IsSatisfiedBy(p) || s.right.IsSatisfiedBy(p)
+func RunningSpecification() ProcessSpecification {
+	return statusLeaf("running")
 }
 
-func (s *orSpecification) And(other ProcessSpecification) ProcessSpecification {
-	return &andSpecification{s, other}
+func HighPrioritySpecification() ProcessSpecification {
+	return minPriorityLeaf(5)
 }
 
-func (s *orSpecification) Or(other ProcessSpecification) ProcessSpecification {
-	return &orSpecification{s, other}
+func OwnerSpecification(owner string) ProcessSpecification {
+	return ownerLeaf(owner)
 }
 
-func (s *orSpecification) Not() ProcessSpecification {
-	return &notSpecification{s}
+// processLeaf creates a specification leaf for a predicate that compares a
+// single Process column, so the leaf can also be rendered as SQL or JSON.
+func processLeaf(predicate ProcessPredicate, column, op string, value any) ProcessSpecification {
+	return &baseSpecification{
+		predicate: predicate,
+		condition: &sqlCondition{column: column, op: op, value: value},
+	}
 }
 
-type notSpecification struct {
-	spec ProcessSpecification
+func titleLeaf(title string) ProcessSpecification {
+	return processLeaf(ByTitle(title), "title", "=", title)
 }
 
-func (s *notSpecification) IsSatisfiedBy(p *Process) bool {
-	return !s.spec.IsSatisfiedBy(p)
+func idLeaf(id int) ProcessSpecification {
+	return processLeaf(ByID(id), "id", "=", id)
 }
 
-func (s *notSpecification) And(other ProcessSpecification) ProcessSpecification {
-	return &andSpecification{s, other}
+func statusLeaf(status string) ProcessSpecification {
+	return processLeaf(ByStatus(status), "status", "=", status)
 }
 
-func (s *notSpecification) Or(other ProcessSpecification) ProcessSpecification {
-	return &orSpecification{s, other}
+func minPriorityLeaf(priority int) ProcessSpecification {
+	return processLeaf(ByMinPriority(priority), "priority", ">=", priority)
 }
 
-func (s *notSpecification) Not() ProcessSpecification {
-	return s.spec // Double negation
+func ownerLeaf(owner string) ProcessSpecification {
+	return processLeaf(ByOwner(owner), "owner", "=", owner)
 }
 
-// Specification constructors
-
-func RunningSpecification() ProcessSpecification {
-	return &baseSpecification{
-		predicate: func(p *Process) bool {
-			return p.Status == "running"
-		},
-	}
+func maxCPULeaf(maxCPU float64) ProcessSpecification {
+	return processLeaf(ByMaxCPU(maxCPU), "cpu_usage", "<=", maxCPU)
 }
 
-func HighPrioritySpecification() ProcessSpecification {
-	return &baseSpecification{
-		predicate: func(p *Process) bool {
-			return p.Priority >= 5
-		},
-	}
+func minMemoryLeaf(minMemory int64) ProcessSpecification {
+	return processLeaf(ByMinMemory(minMemory), "memory", ">=", minMemory)
 }
 
-func OwnerSpecification(owner string) ProcessSpecification {
-	return &baseSpecification{
-		predicate: func(p *Process) bool {
-			return p.Owner == owner
-		},
-	}
+func startedAfterLeaf(t time.Time) ProcessSpecification {
+	return processLeaf(ByStartedAfter(t), "started_at", ">", t)
 }
 
 // Demo specification pattern
 func DemoSpecificationPattern() {
-	fmt.Println("\n=== Specification Pattern Example ===\n")
+	fmt.Print("\n=== Specification Pattern Example ===\n\n")
 
 	pm := CreateProcessManager()
 
@@ -454,4 +715,47 @@ func DemoSpecificationPattern() {
 	for _, p := range result {
 		fmt.Printf("   - %s\n", p)
 	}
+
+	// Ask why a process was rejected
+	fmt.Println("\nExplain: why is Python not in the results?")
+	explanation := ExplainSpecification(spec, pm.GetAll()[1])
+	fmt.Printf("   %s\n", explanation.Reason())
+	for _, line := range strings.Split(explanation.String(), "\n") {
+		fmt.Printf("   %s\n", line)
+	}
+
+	// Save the specification as JSON and load it back
+	fmt.Println("\nAs JSON:")
+	data, err := MarshalSpecification(spec)
+	if err != nil {
+		fmt.Printf("   error: %v\n", err)
+		return
+	}
+	fmt.Printf("   %s\n", data)
+	loaded, err := UnmarshalSpecification(data)
+	if err != nil {
+		fmt.Printf("   error: %v\n", err)
+		return
+	}
+	fmt.Printf("   Loaded specification matches %d process(es)\n", len(pm.Find(loaded.IsSatisfiedBy)))
+	if _, err := UnmarshalSpecification([]byte(`{"field":"owner","op":"gt","value":"user1"}`)); err != nil {
+		fmt.Printf("   Rejected: %v\n", err)
+	}
+
+	// The same specification rendered for the database
+	fmt.Println("\nAs SQL:")
+	where, args, err := ToSQL(spec, DollarDialect)
+	if err != nil {
+		fmt.Printf("   error: %v\n", err)
+		return
+	}
+	fmt.Printf("   WHERE %s\n", where)
+	fmt.Printf("   args: %v\n", args)
+
+	custom := spec.Or(NewSpecification(func(p *Process) bool {
+		return p.CPUUsage > 40
+	}))
+	if _, _, err := ToSQL(custom, QuestionDialect); err != nil {
+		fmt.Printf("   custom predicate: %v\n", err)
+	}
 }
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+
+// titles returns the titles of processes in order.
+func titles(processes []*Process) []string {
+	result := make([]string, len(processes))
+	for i, p := range processes {
+		result[i] = p.Title
+	}
+	return result
+}
+
+func TestWithStartedWithin(t *testing.T) {
+	pm := CreateProcessManager()
+	builder := NewProcessPredicateBuilder().
+		WithClock(func() time.Time { return sampleTime }).
+		WithStatus("running").
+		WithStartedWithin(3 * time.Hour)
+
+	want := []string{"Python", "Java", "Rust"}
+	if got := titles(pm.Find(builder.Build())); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	where, args, err := ToSQL(builder.Specification(), DollarDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if where != "(status = $1 AND started_at > $2)" {
+		t.Errorf("unexpected SQL %q", where)
+	}
+	if cutoff, ok := args[1].(time.Time); !ok || !cutoff.Equal(sampleTime.Add(-3*time.Hour)) {
+		t.Errorf("expected the window to be anchored at the builder's clock, got %v", args[1])
+	}
+
+	data, err := MarshalSpecification(builder.Specification())
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := UnmarshalSpecification(data)
+	if err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	if got := titles(pm.Find(loaded.IsSatisfiedBy)); !slices.Equal(got, want) {
+		t.Errorf("expected the saved search to find %v, got %v", want, got)
+	}
+}
+
+func TestByStartedWithinSlides(t *testing.T) {
+	pm := CreateProcessManager()
+	now := sampleTime
+	recent := ByStartedWithin(time.Hour, predicate.WithClock(func() time.Time { return now }))
+
+	if got := titles(pm.Find(recent)); !slices.Equal(got, []string{"Python", "Rust"}) {
+		t.Errorf("expected [Python Rust], got %v", got)
+	}
+	now = now.Add(45 * time.Minute)
+	if got := titles(pm.Find(recent)); !slices.Equal(got, []string{"Rust"}) {
+		t.Errorf("expected [Rust] once the clock moves, got %v", got)
+	}
+}
+
+func TestBuilderGroups(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *ProcessPredicateBuilder
+		sql     string
+		want    []string
+	}{
+		{
+			"and with any group",
+			NewProcessPredicateBuilder().
+				WithStatus("running").
+				AnyOf(func(g *ProcessPredicateBuilder) { g.WithOwner("user1").WithOwner("user2") }),
+			"(status = ? AND (owner = ? OR owner = ?))",
+			[]string{"Go", "Python", "Rust"},
+		},
+		{
+			"nested groups",
+			NewProcessPredicateBuilder().
+				AnyOf(func(g *ProcessPredicateBuilder) {
+					g.WithTitle("Node").
+						AllOf(func(g *ProcessPredicateBuilder) { g.WithOwner("user1").WithMinPriority(6) })
+				}),
+			"((title = ? OR (owner = ? AND priority >= ?)))",
+			[]string{"C++", "Rust", "Node"},
+		},
+		{
+			"not group",
+			NewProcessPredicateBuilder().
+				WithStatus("running").
+				Not(func(g *ProcessPredicateBuilder) {
+					g.AnyOf(func(g *ProcessPredicateBuilder) { g.WithOwner("user1").WithOwner("user3") })
+				}),
+			"(status = ? AND NOT (owner = ? OR owner = ?))",
+			[]string{"Python"},
+		},
+		{
+			"not of several conditions",
+			NewProcessPredicateBuilder().
+				Not(func(g *ProcessPredicateBuilder) { g.WithStatus("running").WithMinPriority(4) }),
+			"(NOT (status = ? AND priority >= ?))",
+			[]string{"Python", "C++", "Node"},
+		},
+	}
+
+	pm := CreateProcessManager()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, _, err := ToSQL(tt.builder.Specification(), QuestionDialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if where != tt.sql {
+				t.Errorf("expected %s, got %s", tt.sql, where)
+			}
+			got := pm.Find(tt.builder.Build())
+			if !slices.Equal(titles(got), tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, titles(got))
+			}
+			// Groups are ordinary specification nodes, so they also round-trip
+			if _, err := MarshalSpecification(tt.builder.Specification()); err != nil {
+				t.Errorf("marshal: %v", err)
+			}
+		})
+	}
+}
+
+func TestEmptyAnyGroup(t *testing.T) {
+	builder := NewProcessPredicateBuilder().AnyOf(func(*ProcessPredicateBuilder) {})
+
+	where, _, err := ToSQL(builder.Specification(), QuestionDialect)
+	if err != nil || where != "(FALSE)" {
+		t.Errorf("expected (FALSE), got %q (%v)", where, err)
+	}
+	if got := CreateProcessManager().Find(builder.Build()); len(got) != 0 {
+		t.Errorf("expected no processes, got %v", titles(got))
+	}
+	// A list without operands has no document form
+	if _, err := MarshalSpecification(builder.Specification()); !errors.Is(err, ErrMalformedSpecification) {
+		t.Errorf("expected ErrMalformedSpecification, got %v", err)
+	}
+}
+
+func TestBuilderRejectsMixedCombinators(t *testing.T) {
+	anyOwner := func(g *ProcessPredicateBuilder) { g.WithOwner("user1").WithOwner("user2") }
+	tests := []struct {
+		name    string
+		builder *ProcessPredicateBuilder
+		sql     string // the misused calls are ignored
+		err     string // the first misuse
+	}{
+		{"switch after group", NewProcessPredicateBuilder().AnyOf(anyOwner).UseOR(), "((owner = ? OR owner = ?))", "UseOR on a builder with groups"},
+		{"group after switch", NewProcessPredicateBuilder().UseAND().WithStatus("running").AnyOf(anyOwner), "(status = ?)", "group added after UseAND"},
+		{
+			"switch inside group",
+			NewProcessPredicateBuilder().AllOf(func(g *ProcessPredicateBuilder) { g.WithOwner("user1").UseOR().WithMinPriority(5) }),
+			"((owner = ? AND priority >= ?))",
+			"UseOR inside a group",
+		},
+		{"switch after conditions", NewProcessPredicateBuilder().WithOwner("user1").WithOwner("user2").UseOR(), "(owner = ? AND owner = ?)", "UseOR after 2 condition(s)"},
+		{"first misuse wins", NewProcessPredicateBuilder().WithOwner("user1").UseOR().UseAND().AnyOf(anyOwner), "(owner = ?)", "UseOR after 1 condition(s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.builder.Err()
+			if !errors.Is(err, ErrMixedCombinators) || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("expected ErrMixedCombinators for %q, got %v", tt.err, err)
+			}
+			where, _, err := ToSQL(tt.builder.Specification(), QuestionDialect)
+			if err != nil || where != tt.sql {
+				t.Errorf("expected %s, got %s (%v)", tt.sql, where, err)
+			}
+		})
+	}
+
+	// Choosing the combinator before adding conditions stays allowed
+	b := NewProcessPredicateBuilder().UseOR().WithOwner("user3").WithTitle("Go").UseOR()
+	if err := b.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(CreateProcessManager().Find(b.Build())); !slices.Equal(got, []string{"Go", "Java"}) {
+		t.Errorf("expected [Go Java], got %v", got)
+	}
+}
+
+func TestBuildE(t *testing.T) {
+	pred, err := NewProcessPredicateBuilder().WithOwner("user1").UseOR().BuildE()
+	if !errors.Is(err, ErrMixedCombinators) || pred != nil {
+		t.Errorf("expected no predicate and ErrMixedCombinators, got %v", err)
+	}
+
+	pred, err = NewProcessPredicateBuilder().UseOR().WithOwner("user3").WithTitle("Go").BuildE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(CreateProcessManager().Find(pred)); !slices.Equal(got, []string{"Go", "Java"}) {
+		t.Errorf("expected [Go Java], got %v", got)
+	}
+}
+
+func TestBuildIsSnapshot(t *testing.T) {
+	pm := CreateProcessManager()
+	b := NewProcessPredicateBuilder().WithStatus("running")
+	running := b.Build()
+	spec := b.Specification()
+
+	b.WithOwner("user1").WithMinPriority(6)
+
+	if got := len(pm.Find(running)); got != 4 {
+		t.Errorf("expected the built predicate to keep matching 4 running processes, got %d", got)
+	}
+	if got := len(pm.Find(spec.IsSatisfiedBy)); got != 4 {
+		t.Errorf("expected the specification to keep matching 4 running processes, got %d", got)
+	}
+	if got := titles(pm.Find(b.Build())); !slices.Equal(got, []string{"Rust"}) {
+		t.Errorf("expected a new Build to see the added conditions, got %v", got)
+	}
+}
+
+func TestCloneAndForkAreIndependent(t *testing.T) {
+	pm := CreateProcessManager()
+	// Leave spare capacity so a shared backing array would be overwritten
+	base := NewProcessPredicateBuilder().WithStatus("running")
+	base.predicates = slices.Grow(base.predicates, 4)
+
+	mine := base.Clone().WithOwner("user1")
+	theirs := base.Fork(func(b *ProcessPredicateBuilder) { b.WithOwner("user2") })
+
+	if got := titles(pm.Find(mine.Build())); !slices.Equal(got, []string{"Go", "Rust"}) {
+		t.Errorf("expected [Go Rust], got %v", got)
+	}
+	if got := titles(pm.Find(theirs.Build())); !slices.Equal(got, []string{"Python"}) {
+		t.Errorf("expected [Python], got %v", got)
+	}
+	if got := len(pm.Find(base.Build())); got != 4 {
+		t.Errorf("expected the base to be unchanged, got %d matches", got)
+	}
+}
+
+func TestConcurrentForks(t *testing.T) {
+	pm := CreateProcessManager()
+	base := NewProcessPredicateBuilder().
+		WithStatus("running").
+		WithMinMemory(1024)
+	want := map[string][]string{
+		"user1": {"Go", "Rust"},
+		"user2": {"Python"},
+		"user3": {"Java"},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := range 64 {
+		owner := fmt.Sprintf("user%d", i%3+1)
+		wg.Go(func() {
+			fork := base.Fork(func(b *ProcessPredicateBuilder) { b.WithOwner(owner) })
+			if i%2 == 0 {
+				fork.WithMaxCPU(100) // keep modifying the fork after forking
+			}
+			if got := titles(pm.Find(fork.Build())); !slices.Equal(got, want[owner]) {
+				errs <- fmt.Errorf("%s: expected %v, got %v", owner, want[owner], got)
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if got := len(base.predicates); got != 2 {
+		t.Errorf("expected the shared base to keep 2 conditions, got %d", got)
+	}
+}
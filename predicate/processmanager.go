@@ -0,0 +1,220 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vdntruong/gopatterns/pkg/must"
+)
+
+// Managing processes
+//
+// A ProcessManager owns its processes. Add, Upsert and Update store copies,
+// and every query returns copies, so the stored processes can only change
+// through the manager, under its lock, and results handed out earlier never
+// change underneath their holders. Every change increments Version, so a
+// caller can tell whether anything changed between two reads, and is
+// published to the live queries started with Subscribe.
+
+// ErrDuplicateProcess is returned by Add for a process whose ID is taken.
+var ErrDuplicateProcess = errors.New("duplicate process ID")
+
+// ProcessManager manages a collection of processes. It is safe for
+// concurrent use. Predicates passed to its queries run under a read lock and
+// must not call back into the manager's mutating methods. The function passed
+// to Update runs under the write lock and must not call back into the manager
+// at all.
+type ProcessManager struct {
+	mu        sync.RWMutex
+	processes []Process
+	positions map[int]int             // process ID to position in processes
+	indexes   map[string]processIndex // secondary indexes by column
+	version   uint64
+
+	subscribers map[*Subscription]struct{} // live queries, see Subscribe
+}
+
+// NewProcessManager creates an empty process manager.
+func NewProcessManager() *ProcessManager {
+	return &ProcessManager{positions: make(map[int]int)}
+}
+
+// sampleTime is the moment the sample processes are observed at; their start
+// times are fixed relative to it so demos and tests are deterministic.
+var sampleTime = time.Date(2025, time.March, 14, 12, 0, 0, 0, time.UTC)
+
+// CreateProcessManager creates a new process manager with sample data
+func CreateProcessManager() *ProcessManager {
+	pm := NewProcessManager()
+	for _, p := range []Process{
+		{ID: 1, Title: "Go", Status: "running", Priority: 5, Owner: "user1", CPUUsage: 25.5, Memory: 1024, StartedAt: sampleTime.Add(-72 * time.Hour)},
+		{ID: 2, Title: "Python", Status: "running", Priority: 3, Owner: "user2", CPUUsage: 15.2, Memory: 2048, StartedAt: sampleTime.Add(-30 * time.Minute)},
+		{ID: 3, Title: "C++", Status: "stopped", Priority: 7, Owner: "user1", CPUUsage: 0.0, Memory: 512, StartedAt: sampleTime.Add(-5 * time.Hour)},
+		{ID: 4, Title: "Java", Status: "running", Priority: 4, Owner: "user3", CPUUsage: 45.8, Memory: 4096, StartedAt: sampleTime.Add(-2 * time.Hour)},
+		{ID: 5, Title: "Rust", Status: "running", Priority: 6, Owner: "user1", CPUUsage: 10.3, Memory: 1536, StartedAt: sampleTime.Add(-10 * time.Minute)},
+		{ID: 6, Title: "Node", Status: "stopped", Priority: 2, Owner: "user2", CPUUsage: 0.0, Memory: 768, StartedAt: sampleTime.Add(-26 * time.Hour)},
+	} {
+		must.Must(pm.Add(p))
+	}
+	return pm
+}
+
+// Find filters processes using a predicate. It returns copies in insertion
+// order.
+func (pm *ProcessManager) Find(predicate ProcessPredicate) []*Process {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.find(predicate)
+}
+
+func (pm *ProcessManager) find(predicate ProcessPredicate) []*Process {
+	var result []*Process
+	for _, p := range pm.processes {
+		// p is a fresh copy each iteration, so the predicate cannot modify
+		// the stored process and the result can keep a pointer to it
+		if predicate(&p) {
+			result = append(result, &p)
+		}
+	}
+	return result
+}
+
+// Get returns a copy of the process with the given ID.
+func (pm *ProcessManager) Get(id int) (*Process, bool) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	pos, ok := pm.positions[id]
+	if !ok {
+		return nil, false
+	}
+	p := pm.processes[pos]
+	return &p, true
+}
+
+// GetAll returns copies of all processes
+func (pm *ProcessManager) GetAll() []*Process {
+	processes, _ := pm.Snapshot()
+	return processes
+}
+
+// Snapshot returns copies of all processes together with the version they
+// reflect, read atomically.
+func (pm *ProcessManager) Snapshot() ([]*Process, uint64) {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.find(func(*Process) bool { return true }), pm.version
+}
+
+// Version returns a counter that increases with every change made through
+// Add, Upsert, Update and Remove.
+func (pm *ProcessManager) Version() uint64 {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+	return pm.version
+}
+
+// Add stores a copy of p after the existing processes and records it in the
+// declared indexes. It returns ErrDuplicateProcess if the ID is taken.
+func (pm *ProcessManager) Add(p Process) error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if _, exists := pm.positions[p.ID]; exists {
+		return fmt.Errorf("%w: %d", ErrDuplicateProcess, p.ID)
+	}
+	pm.insert(p)
+	pm.version++
+	pm.publish(nil, &p)
+	return nil
+}
+
+// Upsert stores a copy of p, replacing the process with the same ID in place
+// or adding it after the existing processes. It reports whether p was added.
+func (pm *ProcessManager) Upsert(p Process) (added bool) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pos, exists := pm.positions[p.ID]
+	if !exists {
+		pm.insert(p)
+		pm.version++
+		pm.publish(nil, &p)
+		return true
+	}
+	old := pm.processes[pos]
+	pm.replace(pos, p)
+	pm.version++
+	pm.publish(&old, &p)
+	return false
+}
+
+// Update applies change to a copy of the process with the given ID, stores
+// the result and refreshes the declared indexes. Changes to the ID are
+// ignored. It reports whether the process was found.
+//
+// change runs under the manager's write lock, so it must not call any method
+// of the manager, not even a query; doing so deadlocks.
+func (pm *ProcessManager) Update(id int, change func(*Process)) bool {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pos, ok := pm.positions[id]
+	if !ok {
+		return false
+	}
+	old := pm.processes[pos]
+	p := old
+	change(&p)
+	p.ID = id
+	pm.replace(pos, p)
+	pm.version++
+	pm.publish(&old, &p)
+	return true
+}
+
+// Remove deletes the process with the given ID and reports whether it was
+// found. Later processes move up one position, and the declared indexes move
+// their positions along in place, which costs a pass over each index.
+func (pm *ProcessManager) Remove(id int) bool {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pos, ok := pm.positions[id]
+	if !ok {
+		return false
+	}
+	removed := pm.processes[pos]
+	pm.processes = slices.Delete(pm.processes, pos, pos+1)
+	delete(pm.positions, id)
+	for i := pos; i < len(pm.processes); i++ {
+		pm.positions[pm.processes[i].ID] = i
+	}
+	for column, index := range pm.indexes {
+		index.shift(processColumns[column](&removed), pos)
+	}
+	pm.version++
+	pm.publish(&removed, nil)
+	return true
+}
+
+func (pm *ProcessManager) insert(p Process) {
+	pos := len(pm.processes)
+	pm.processes = append(pm.processes, p)
+	pm.positions[p.ID] = pos
+	for column, index := range pm.indexes {
+		index.insert(processColumns[column](&p), pos)
+	}
+}
+
+// replace stores p at pos, moving it between index entries only for the
+// columns whose value changed.
+func (pm *ProcessManager) replace(pos int, p Process) {
+	old := pm.processes[pos]
+	pm.processes[pos] = p
+	for column, index := range pm.indexes {
+		get := processColumns[column]
+		if before, after := get(&old), get(&p); before != after {
+			index.remove(before, pos)
+			index.insert(after, pos)
+		}
+	}
+}
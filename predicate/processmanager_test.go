@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestProcessManagerReturnsCopies(t *testing.T) {
+	pm := CreateProcessManager()
+
+	found := pm.Find(ByOwner("user1"))
+	found[0].Status = "hijacked"
+	all := pm.GetAll()
+	all[1].Owner = "mallory"
+	got, _ := pm.Get(3)
+	got.Priority = 0
+	pm.Find(func(p *Process) bool {
+		p.Title = "changed by a predicate"
+		return false
+	})
+
+	p, ok := pm.Get(1)
+	if !ok || p.Status != "running" || p.Title != "Go" {
+		t.Errorf("expected process 1 to be unchanged, got %v", p)
+	}
+	if owners := len(pm.Find(ByOwner("mallory"))); owners != 0 {
+		t.Errorf("expected no process owned by mallory, got %d", owners)
+	}
+	if p, _ := pm.Get(3); p.Priority != 7 {
+		t.Errorf("expected process 3 to keep priority 7, got %d", p.Priority)
+	}
+	if _, ok := pm.Get(99); ok {
+		t.Error("expected an unknown ID to be reported")
+	}
+}
+
+func TestProcessManagerMutations(t *testing.T) {
+	pm := CreateProcessManager()
+	start := pm.Version()
+
+	if err := pm.Add(Process{ID: 3, Title: "Duplicate"}); !errors.Is(err, ErrDuplicateProcess) {
+		t.Errorf("expected ErrDuplicateProcess, got %v", err)
+	}
+	if pm.Version() != start {
+		t.Error("expected a rejected Add to leave the version unchanged")
+	}
+
+	if !pm.Upsert(Process{ID: 7, Title: "Zig", Status: "running", Owner: "user4"}) {
+		t.Error("expected Upsert of a new ID to add it")
+	}
+	if pm.Upsert(Process{ID: 2, Title: "Python", Status: "stopped", Owner: "user2"}) {
+		t.Error("expected Upsert of an existing ID to replace it")
+	}
+	if !pm.Update(5, func(p *Process) { p.ID = 42; p.Priority = 1 }) {
+		t.Error("expected process 5 to be updated")
+	}
+	if !pm.Remove(1) || pm.Remove(1) {
+		t.Error("expected process 1 to be removed exactly once")
+	}
+
+	if got := ids(pm.GetAll()); !slices.Equal(got, []int{2, 3, 4, 5, 6, 7}) {
+		t.Errorf("expected [2 3 4 5 6 7] in insertion order, got %v", got)
+	}
+	if p, _ := pm.Get(5); p.Priority != 1 {
+		t.Errorf("expected the update to apply while keeping ID 5, got %v", p)
+	}
+	if got := titles(pm.Find(ByStatus("running"))); !slices.Equal(got, []string{"Java", "Rust", "Zig"}) {
+		t.Errorf("expected [Java Rust Zig] running, got %v", got)
+	}
+
+	processes, version := pm.Snapshot()
+	if version != start+4 || version != pm.Version() {
+		t.Errorf("expected version %d, got %d", start+4, version)
+	}
+	if len(processes) != 6 {
+		t.Errorf("expected 6 processes in the snapshot, got %d", len(processes))
+	}
+}
+
+func TestIndexesFollowRemoveAndUpsert(t *testing.T) {
+	pm := indexedManager(t, "status", "owner", "priority")
+
+	pm.Remove(2)
+	pm.Upsert(Process{ID: 8, Title: "Kotlin", Status: "running", Owner: "user2", Priority: 9})
+	pm.Upsert(Process{ID: 4, Title: "Java", Status: "stopped", Owner: "user3", Priority: 4})
+	pm.Remove(1)
+
+	specs := []ProcessSpecification{
+		statusLeaf("running"),
+		ownerLeaf("user2"),
+		minPriorityLeaf(6),
+		statusLeaf("stopped").And(ownerLeaf("user3")),
+	}
+	for _, spec := range specs {
+		want := ids(pm.Find(spec.IsSatisfiedBy))
+		if got := ids(pm.FindSpecification(spec)); !slices.Equal(got, want) {
+			t.Errorf("%v: expected %v from the indexes, got %v", describeSpecification(spec).Expr, want, got)
+		}
+	}
+}
+
+func TestRemoveShiftsIndexes(t *testing.T) {
+	pm := indexedManager(t, "status", "title", "priority", "cpu_usage")
+
+	// First, middle and last positions
+	for _, id := range []int{1, 4, 6} {
+		pm.Remove(id)
+		for column, index := range pm.indexes {
+			if rebuilt := pm.buildIndex(column); !reflect.DeepEqual(index, rebuilt) {
+				t.Errorf("after Remove(%d), %s index differs from a rebuilt one", id, column)
+			}
+		}
+	}
+}
+
+func TestProcessManagerConcurrentUse(t *testing.T) {
+	pm := indexedManager(t, "status", "priority")
+
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Go(func() {
+			for i := range 50 {
+				id := 100 + w*50 + i
+				if err := pm.Add(Process{ID: id, Title: fmt.Sprint(id), Status: "running", Priority: i % 10}); err != nil {
+					t.Error(err)
+					return
+				}
+				pm.Update(id, func(p *Process) { p.Status = "stopped" })
+				if i%2 == 0 {
+					pm.Remove(id)
+				}
+			}
+		})
+	}
+	for range 4 {
+		wg.Go(func() {
+			for range 50 {
+				pm.FindSpecification(statusLeaf("stopped").And(minPriorityLeaf(5)))
+				for _, p := range pm.Find(ByStatus("running")) {
+					p.Status = "local copy only"
+				}
+				pm.Snapshot()
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := len(pm.GetAll()); got != 6+4*25 {
+		t.Errorf("expected %d processes, got %d", 6+4*25, got)
+	}
+	if got := pm.Version(); got != 6+4*(50+50+25) {
+		t.Errorf("expected version %d, got %d", 6+4*(50+50+25), got)
+	}
+	want := ids(pm.Find(ByStatus("stopped")))
+	if got := ids(pm.FindSpecification(statusLeaf("stopped"))); !slices.Equal(got, want) {
+		t.Errorf("expected the index to agree with a scan after concurrent changes")
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate/spec"
+)
+
+// Saving specifications as JSON
+//
+// Saved searches are stored as JSON documents such as
+//
+//	{"and": [{"field": "status", "op": "eq", "value": "running"},
+//	         {"not": {"field": "owner", "op": "eq", "value": "user2"}}]}
+//
+// Leaves are rebuilt through a registry of known constructors, so a document
+// can only ever produce conditions the application knows how to evaluate.
+
+var (
+	// ErrUnknownLeaf is returned for leaves that are not in the registry,
+	// including opaque Go predicates that cannot be saved.
+	ErrUnknownLeaf = errors.New("unknown specification leaf")
+	// ErrMalformedSpecification is returned for documents that are not valid
+	// specification trees or whose leaf values have the wrong type.
+	ErrMalformedSpecification = errors.New("malformed specification")
+)
+
+// LeafDecoder rebuilds a specification leaf from its JSON value.
+type LeafDecoder func(value json.RawMessage) (ProcessSpecification, error)
+
+// LeafOf adapts a typed leaf constructor to a LeafDecoder. The value must
+// decode into V exactly; for example 2.5 is rejected for an int leaf.
+func LeafOf[V any](build func(V) ProcessSpecification) LeafDecoder {
+	return func(value json.RawMessage) (ProcessSpecification, error) {
+		var v V
+		if len(value) == 0 || bytes.Equal(value, []byte("null")) {
+			return nil, fmt.Errorf("%w: missing value, expected %T", ErrMalformedSpecification, v)
+		}
+		if err := json.Unmarshal(value, &v); err != nil {
+			return nil, fmt.Errorf("%w: value %s is not a valid %T", ErrMalformedSpecification, value, v)
+		}
+		return build(v), nil
+	}
+}
+
+// SpecificationRegistry maps leaf field/op pairs to their constructors.
+type SpecificationRegistry struct {
+	leaves map[leafKey]LeafDecoder
+}
+
+type leafKey struct {
+	field, op string
+}
+
+// NewSpecificationRegistry creates an empty registry.
+func NewSpecificationRegistry() *SpecificationRegistry {
+	return &SpecificationRegistry{leaves: map[leafKey]LeafDecoder{}}
+}
+
+// Register adds a leaf constructor for a field and JSON operator (eq, ne, lt,
+// lte, gt, gte), replacing any previous registration.
+func (r *SpecificationRegistry) Register(field, op string, decode LeafDecoder) {
+	r.leaves[leafKey{field, op}] = decode
+}
+
+// DefaultSpecificationRegistry knows every leaf that ProcessPredicateBuilder
+// and the specification constructors can produce.
+var DefaultSpecificationRegistry = newProcessRegistry()
+
+func newProcessRegistry() *SpecificationRegistry {
+	r := NewSpecificationRegistry()
+	r.Register("id", "eq", LeafOf(idLeaf))
+	r.Register("title", "eq", LeafOf(titleLeaf))
+	r.Register("status", "eq", LeafOf(statusLeaf))
+	r.Register("owner", "eq", LeafOf(OwnerSpecification))
+	r.Register("priority", "gte", LeafOf(minPriorityLeaf))
+	r.Register("cpu_usage", "lte", LeafOf(maxCPULeaf))
+	r.Register("memory", "gte", LeafOf(minMemoryLeaf))
+	r.Register("started_at", "gt", LeafOf(startedAfterLeaf))
+	return r
+}
+
+// MarshalSpecification encodes spec with the default registry.
+func MarshalSpecification(spec ProcessSpecification) ([]byte, error) {
+	return DefaultSpecificationRegistry.Marshal(spec)
+}
+
+// UnmarshalSpecification decodes a document with the default registry.
+func UnmarshalSpecification(data []byte) (ProcessSpecification, error) {
+	return DefaultSpecificationRegistry.Unmarshal(data)
+}
+
+// specDocument is the JSON form of a specification node. Exactly one of
+// And, Or, Not or Field is set.
+type specDocument struct {
+	And   []*specDocument `json:"and,omitempty"`
+	Or    []*specDocument `json:"or,omitempty"`
+	Not   *specDocument   `json:"not,omitempty"`
+	Field string          `json:"field,omitempty"`
+	Op    string          `json:"op,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// jsonOps maps SQL comparison operators to their JSON names.
+var jsonOps = map[string]string{
+	"=":  "eq",
+	"!=": "ne",
+	"<":  "lt",
+	"<=": "lte",
+	">":  "gt",
+	">=": "gte",
+}
+
+// Marshal encodes a specification. Chains of the same operator are flattened,
+// so a.And(b).And(c) is saved as a single "and" list.
+func (r *SpecificationRegistry) Marshal(spec ProcessSpecification) ([]byte, error) {
+	doc, err := r.encode(spec)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+func (r *SpecificationRegistry) encode(spec ProcessSpecification) (*specDocument, error) {
+	switch s := spec.(type) {
+	case *andSpecification:
+		operands, err := r.encodeChain(s)
+		return &specDocument{And: operands}, err
+	case *orSpecification:
+		operands, err := r.encodeChain(s)
+		return &specDocument{Or: operands}, err
+	case *notSpecification:
+		operand, err := r.encode(s.Operand)
+		return &specDocument{Not: operand}, err
+	case *baseSpecification:
+		if s.condition == nil {
+			return nil, fmt.Errorf("%w: opaque Go predicate cannot be saved", ErrUnknownLeaf)
+		}
+		doc := &specDocument{Field: s.condition.column, Op: jsonOps[s.condition.op]}
+		if _, ok := r.leaves[leafKey{doc.Field, doc.Op}]; !ok {
+			return nil, fmt.Errorf("%w: %s %s is not registered", ErrUnknownLeaf, doc.Field, doc.Op)
+		}
+		value, err := json.Marshal(s.condition.value)
+		if err != nil {
+			return nil, err
+		}
+		doc.Value = value
+		return doc, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported specification type %T", ErrUnknownLeaf, spec)
+}
+
+// encodeChain collects the operands of nested nodes of the same type. Like
+// the decoder, it rejects a chain without operands, such as AllOf(), which
+// has no document form.
+func (r *SpecificationRegistry) encodeChain(root ProcessSpecification) ([]*specDocument, error) {
+	var operands []*specDocument
+	var walk func(ProcessSpecification) error
+	walk = func(s ProcessSpecification) error {
+		children, ok := chainOperands(root, s)
+		if !ok {
+			doc, err := r.encode(s)
+			operands = append(operands, doc)
+			return err
+		}
+		for _, child := range children {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(root); err != nil {
+		return nil, err
+	}
+	if len(operands) == 0 {
+		return nil, fmt.Errorf("%w: and/or needs at least one operand", ErrMalformedSpecification)
+	}
+	return operands, nil
+}
+
+// chainOperands returns the operands of s if it has the same type as root.
+func chainOperands(root, s ProcessSpecification) ([]ProcessSpecification, bool) {
+	switch s := s.(type) {
+	case *andSpecification:
+		if _, same := root.(*andSpecification); same {
+			return s.Operands, true
+		}
+	case *orSpecification:
+		if _, same := root.(*orSpecification); same {
+			return s.Operands, true
+		}
+	}
+	return nil, false
+}
+
+// Unmarshal decodes a document into a specification tree. Errors name the
+// offending node with a path such as and[1].not.
+func (r *SpecificationRegistry) Unmarshal(data []byte) (ProcessSpecification, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var doc *specDocument
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedSpecification, err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("%w: unexpected data after document", ErrMalformedSpecification)
+	}
+	return r.decode(doc, "$")
+}
+
+func (r *SpecificationRegistry) decode(doc *specDocument, path string) (ProcessSpecification, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("%s: %w: empty node", path, ErrMalformedSpecification)
+	}
+
+	kinds := 0
+	for _, set := range []bool{doc.And != nil, doc.Or != nil, doc.Not != nil, doc.Field != "" || doc.Op != "" || doc.Value != nil} {
+		if set {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return nil, fmt.Errorf("%s: %w: node must have exactly one of and, or, not or field/op/value", path, ErrMalformedSpecification)
+	}
+
+	switch {
+	case doc.And != nil:
+		operands, err := r.decodeList(doc.And, path+".and")
+		if err != nil {
+			return nil, err
+		}
+		return spec.AllOf(operands...), nil
+	case doc.Or != nil:
+		operands, err := r.decodeList(doc.Or, path+".or")
+		if err != nil {
+			return nil, err
+		}
+		return spec.AnyOf(operands...), nil
+	case doc.Not != nil:
+		operand, err := r.decode(doc.Not, path+".not")
+		if err != nil {
+			return nil, err
+		}
+		return operand.Not(), nil
+	}
+
+	if doc.Field == "" || doc.Op == "" {
+		return nil, fmt.Errorf("%s: %w: leaf needs both field and op", path, ErrMalformedSpecification)
+	}
+	decode, ok := r.leaves[leafKey{doc.Field, doc.Op}]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: %s %s", path, ErrUnknownLeaf, doc.Field, doc.Op)
+	}
+	spec, err := decode(doc.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s %s: %w", path, doc.Field, doc.Op, err)
+	}
+	return spec, nil
+}
+
+func (r *SpecificationRegistry) decodeList(docs []*specDocument, path string) ([]ProcessSpecification, error) {
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("%s: %w: needs at least one operand", path, ErrMalformedSpecification)
+	}
+	operands := make([]ProcessSpecification, len(docs))
+	for i, doc := range docs {
+		operand, err := r.decode(doc, fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			return nil, err
+		}
+		operands[i] = operand
+	}
+	return operands, nil
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate/spec"
+)
+
+func TestSpecificationJSONRoundTrip(t *testing.T) {
+	spec := RunningSpecification().
+		And(OwnerSpecification("user1").Or(OwnerSpecification("user2"))).
+		And(HighPrioritySpecification().Not())
+
+	data, err := MarshalSpecification(spec)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"and":[{"field":"status","op":"eq","value":"running"},` +
+		`{"or":[{"field":"owner","op":"eq","value":"user1"},{"field":"owner","op":"eq","value":"user2"}]},` +
+		`{"not":{"field":"priority","op":"gte","value":5}}]}`
+	if string(data) != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, data)
+	}
+
+	loaded, err := UnmarshalSpecification(data)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	for _, p := range CreateProcessManager().GetAll() {
+		if loaded.IsSatisfiedBy(p) != spec.IsSatisfiedBy(p) {
+			t.Errorf("loaded specification disagrees on %s", p)
+		}
+	}
+
+	again, err := MarshalSpecification(loaded)
+	if err != nil || string(again) != want {
+		t.Errorf("expected a stable encoding, got %s (%v)", again, err)
+	}
+}
+
+func TestBuilderSpecificationJSON(t *testing.T) {
+	builder := NewProcessPredicateBuilder().
+		WithStatus("running").
+		WithMaxCPU(12).
+		WithMinMemory(1024)
+
+	data, err := MarshalSpecification(builder.Specification())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	loaded, err := UnmarshalSpecification(data)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	pm := CreateProcessManager()
+	got, want := pm.Find(loaded.IsSatisfiedBy), pm.Find(builder.Build())
+	if len(got) != len(want) || len(got) != 1 || got[0].Title != "Rust" {
+		t.Errorf("expected [Rust], got %v (builder found %v)", got, want)
+	}
+}
+
+func TestUnmarshalSpecificationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		err  error
+		path string
+	}{
+		{"unknown leaf", `{"and":[{"field":"status","op":"eq","value":"running"},{"field":"owner","op":"gt","value":"a"}]}`, ErrUnknownLeaf, "$.and[1]"},
+		{"unknown field", `{"field":"color","op":"eq","value":"red"}`, ErrUnknownLeaf, "$"},
+		{"wrong value type", `{"not":{"field":"priority","op":"gte","value":"high"}}`, ErrMalformedSpecification, "$.not"},
+		{"fractional int", `{"field":"priority","op":"gte","value":2.5}`, ErrMalformedSpecification, "$"},
+		{"missing value", `{"field":"owner","op":"eq"}`, ErrMalformedSpecification, "$"},
+		{"missing op", `{"field":"owner","value":"a"}`, ErrMalformedSpecification, "$"},
+		{"two kinds", `{"and":[{"field":"owner","op":"eq","value":"a"}],"field":"owner"}`, ErrMalformedSpecification, "$"},
+		{"empty list", `{"or":[]}`, ErrMalformedSpecification, "$.or"},
+		{"null operand", `{"and":[null]}`, ErrMalformedSpecification, "$.and[0]"},
+		{"unknown key", `{"field":"owner","op":"eq","value":"a","extra":1}`, ErrMalformedSpecification, ""},
+		{"not json", `{"and":`, ErrMalformedSpecification, ""},
+		{"trailing data", `{"field":"owner","op":"eq","value":"a"} {}`, ErrMalformedSpecification, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UnmarshalSpecification([]byte(tt.doc))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if tt.path != "" && !strings.HasPrefix(err.Error(), tt.path+":") {
+				t.Errorf("expected error at %s, got %v", tt.path, err)
+			}
+		})
+	}
+}
+
+func TestMarshalSpecificationRejectsOpaqueLeaf(t *testing.T) {
+	spec := RunningSpecification().And(NewSpecification(func(p *Process) bool { return p.CPUUsage > 40 }))
+	if _, err := MarshalSpecification(spec); !errors.Is(err, ErrUnknownLeaf) {
+		t.Errorf("expected ErrUnknownLeaf, got %v", err)
+	}
+
+	if _, err := NewSpecificationRegistry().Marshal(RunningSpecification()); !errors.Is(err, ErrUnknownLeaf) {
+		t.Errorf("expected unregistered leaf to be rejected, got %v", err)
+	}
+}
+
+func TestMarshalSpecificationRejectsEmptyLists(t *testing.T) {
+	for name, s := range map[string]ProcessSpecification{
+		"empty and": spec.AllOf[*Process](),
+		"empty or":  RunningSpecification().And(spec.AnyOf[*Process]()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if data, err := MarshalSpecification(s); !errors.Is(err, ErrMalformedSpecification) {
+				t.Errorf("expected ErrMalformedSpecification, got %s (%v)", data, err)
+			}
+		})
+	}
+}
+
+func TestEmptyBuilderSpecification(t *testing.T) {
+	pm := CreateProcessManager()
+	for name, b := range map[string]*ProcessPredicateBuilder{
+		"and": NewProcessPredicateBuilder(),
+		"or":  NewProcessPredicateBuilder().UseOR(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got, want := len(pm.Find(b.Specification().IsSatisfiedBy)), len(pm.Find(b.Build())); got != 6 || want != 6 {
+				t.Errorf("expected Specification and Build to match all 6 processes, got %d and %d", got, want)
+			}
+			// Like an opaque leaf, an empty builder cannot be saved
+			if data, err := MarshalSpecification(b.Specification()); !errors.Is(err, ErrUnknownLeaf) {
+				t.Errorf("expected ErrUnknownLeaf, got %s (%v)", data, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Translating specifications to SQL
+//
+// The same specification tree that filters processes in memory can be
+// rendered as a parameterized WHERE clause, so a condition is written once
+// and used both in tests and against the database.
+
+// ErrUntranslatable is returned when a specification contains a leaf that
+// is an opaque Go predicate with no SQL equivalent.
+var ErrUntranslatable = errors.New("specification cannot be translated to SQL")
+
+// Dialect selects the placeholder style used for query arguments.
+type Dialect int
+
+const (
+	// QuestionDialect uses "?" placeholders (MySQL, SQLite).
+	QuestionDialect Dialect = iota
+	// DollarDialect uses numbered "$1", "$2", ... placeholders (PostgreSQL).
+	DollarDialect
+)
+
+// sqlCondition describes a specification leaf as a column comparison.
+type sqlCondition struct {
+	column string
+	op     string
+	value  any
+}
+
+// ToSQL renders a specification as a SQL WHERE fragment (without the WHERE
+// keyword) and the arguments for its placeholders, in order.
+func ToSQL(spec ProcessSpecification, dialect Dialect) (string, []any, error) {
+	w := &sqlWriter{dialect: dialect}
+	if err := w.write(spec); err != nil {
+		return "", nil, err
+	}
+	return w.sb.String(), w.args, nil
+}
+
+type sqlWriter struct {
+	dialect Dialect
+	sb      strings.Builder
+	args    []any
+	leaves  int
+}
+
+func (w *sqlWriter) write(spec ProcessSpecification) error {
+	switch s := spec.(type) {
+	case *andSpecification:
+		return w.writeList("AND", "TRUE", s.Operands)
+	case *orSpecification:
+		return w.writeList("OR", "FALSE", s.Operands)
+	case *notSpecification:
+		w.sb.WriteString("NOT ")
+		return w.writeGrouped(s.Operand)
+	case *baseSpecification:
+		w.leaves++
+		if s.condition == nil {
+			return fmt.Errorf("%w: leaf #%d is an opaque Go predicate", ErrUntranslatable, w.leaves)
+		}
+		w.sb.WriteString(s.condition.column)
+		w.sb.WriteString(" ")
+		w.sb.WriteString(s.condition.op)
+		w.sb.WriteString(" ")
+		w.sb.WriteString(w.placeholder(s.condition.value))
+		return nil
+	}
+	return fmt.Errorf("%w: unsupported specification type %T", ErrUntranslatable, spec)
+}
+
+// writeList joins operands with op. An empty list renders as the operator's
+// identity, so AllOf() becomes TRUE and AnyOf() becomes FALSE.
+func (w *sqlWriter) writeList(op, identity string, operands []ProcessSpecification) error {
+	if len(operands) == 0 {
+		w.sb.WriteString(identity)
+		return nil
+	}
+	w.sb.WriteString("(")
+	for i, operand := range operands {
+		if i > 0 {
+			w.sb.WriteString(" " + op + " ")
+		}
+		if err := w.write(operand); err != nil {
+			return err
+		}
+	}
+	w.sb.WriteString(")")
+	return nil
+}
+
+// writeGrouped wraps leaves in parentheses so NOT applies to the whole
+// comparison; binary nodes already bring their own.
+func (w *sqlWriter) writeGrouped(spec ProcessSpecification) error {
+	if _, ok := spec.(*baseSpecification); !ok {
+		return w.write(spec)
+	}
+	w.sb.WriteString("(")
+	if err := w.write(spec); err != nil {
+		return err
+	}
+	w.sb.WriteString(")")
+	return nil
+}
+
+func (w *sqlWriter) placeholder(value any) string {
+	w.args = append(w.args, value)
+	if w.dialect == DollarDialect {
+		return "$" + strconv.Itoa(len(w.args))
+	}
+	return "?"
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate/spec"
+)
+
+func TestToSQL(t *testing.T) {
+	spec := RunningSpecification().
+		And(OwnerSpecification("user1").Or(OwnerSpecification("user2"))).
+		And(HighPrioritySpecification().Not())
+
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{DollarDialect, "((status = $1 AND (owner = $2 OR owner = $3)) AND NOT (priority >= $4))"},
+		{QuestionDialect, "((status = ? AND (owner = ? OR owner = ?)) AND NOT (priority >= ?))"},
+	}
+
+	for _, tt := range tests {
+		where, args, err := ToSQL(spec, tt.dialect)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if where != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, where)
+		}
+		if want := []any{"running", "user1", "user2", 5}; !slices.Equal(args, want) {
+			t.Errorf("expected args %v, got %v", want, args)
+		}
+	}
+}
+
+func TestToSQLNotOfGroup(t *testing.T) {
+	where, _, err := ToSQL(RunningSpecification().Or(HighPrioritySpecification()).Not(), DollarDialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "NOT (status = $1 OR priority >= $2)"; where != want {
+		t.Errorf("expected %q, got %q", want, where)
+	}
+}
+
+func TestToSQLOpaqueLeaf(t *testing.T) {
+	spec := RunningSpecification().And(NewSpecification(func(p *Process) bool {
+		return p.CPUUsage > 40
+	}))
+
+	_, _, err := ToSQL(spec, QuestionDialect)
+	if !errors.Is(err, ErrUntranslatable) {
+		t.Fatalf("expected ErrUntranslatable, got %v", err)
+	}
+}
+
+func TestToSQLEmptyLists(t *testing.T) {
+	tests := map[string]struct {
+		spec ProcessSpecification
+		want string
+	}{
+		"and": {spec.AllOf[*Process](), "TRUE"},
+		"or":  {spec.AnyOf[*Process](), "FALSE"},
+	}
+	for name, tt := range tests {
+		where, args, err := ToSQL(tt.spec, DollarDialect)
+		if err != nil || where != tt.want || len(args) != 0 {
+			t.Errorf("%s: expected %s with no args, got %q %v (%v)", name, tt.want, where, args, err)
+		}
+	}
+}
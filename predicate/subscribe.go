@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Live queries
+//
+// Instead of re-running Find on a timer, a dashboard can subscribe to a
+// specification and receive an event whenever a process starts matching it
+// (Entered), stops matching it (Left) or changes while still matching it
+// (Updated). Events are produced by Add, Upsert, Update and Remove while
+// they hold the manager's lock, so every subscriber sees changes in the
+// order they were made.
+//
+// Slow consumers: each subscription has a bounded buffer, and the manager
+// never waits for a subscriber. If an event does not fit, the subscription
+// is ended instead of silently dropping the event: its channel is closed and
+// Err returns ErrSlowConsumer. The subscriber should then resubscribe (with
+// WithCurrentMatches to rebuild its state) rather than trust a view that
+// missed a change.
+
+// ErrSlowConsumer ends a subscription whose buffer was full when an event
+// had to be delivered.
+var ErrSlowConsumer = errors.New("subscriber did not keep up with events")
+
+// EventKind says how a change affected a subscription's specification.
+type EventKind int
+
+const (
+	// Entered: the process matches now but did not before, or is new.
+	Entered EventKind = iota + 1
+	// Left: the process matched before but does not now, or was removed.
+	Left
+	// Updated: the process matched before and after a change.
+	Updated
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case Entered:
+		return "entered"
+	case Left:
+		return "left"
+	case Updated:
+		return "updated"
+	}
+	return fmt.Sprintf("EventKind(%d)", int(k))
+}
+
+// ProcessEvent reports one change to the set of processes matching a
+// subscription.
+type ProcessEvent struct {
+	Kind EventKind
+	// Process is the process after the change, or the removed process.
+	Process Process
+	// Previous is the process before the change; the zero Process when the
+	// process was added or reported by WithCurrentMatches.
+	Previous Process
+	// Version is the manager's version after the change.
+	Version uint64
+}
+
+// SubscribeOption configures Subscribe.
+type SubscribeOption func(*subscribeConfig)
+
+type subscribeConfig struct {
+	buffer  int
+	current bool
+}
+
+// WithBuffer sets how many undelivered events a subscription holds before it
+// is ended with ErrSlowConsumer. The default is 64; values below 1 are
+// ignored.
+func WithBuffer(n int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		if n > 0 {
+			c.buffer = n
+		}
+	}
+}
+
+// WithCurrentMatches starts the subscription with an Entered event for every
+// process that already matches, so the subscriber needs no separate Find.
+// Like any other events they must fit in the buffer.
+func WithCurrentMatches() SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.current = true
+	}
+}
+
+// Subscription is a live query on a ProcessManager.
+type Subscription struct {
+	events chan ProcessEvent
+	spec   ProcessSpecification
+	stop   func() bool // stops watching the context
+
+	mu  sync.Mutex
+	err error
+}
+
+// Events returns the channel that delivers the subscription's events. It is
+// closed when the subscription ends; Err then reports why.
+func (s *Subscription) Events() <-chan ProcessEvent {
+	return s.events
+}
+
+// Err returns nil while the subscription is active, and afterwards the
+// context's cancellation cause or ErrSlowConsumer.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Subscribe starts a live query for processes satisfying spec. The
+// subscription ends when ctx is done or when the subscriber falls behind by
+// more than its buffer (see WithBuffer). Like queries, spec is evaluated
+// under the manager's lock and must not call back into the manager.
+func (pm *ProcessManager) Subscribe(ctx context.Context, spec ProcessSpecification, opts ...SubscribeOption) *Subscription {
+	config := subscribeConfig{buffer: 64}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	sub := &Subscription{events: make(chan ProcessEvent, config.buffer), spec: spec}
+
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if ctx.Err() != nil {
+		sub.end(context.Cause(ctx))
+		return sub
+	}
+	if pm.subscribers == nil {
+		pm.subscribers = make(map[*Subscription]struct{})
+	}
+	pm.subscribers[sub] = struct{}{}
+	sub.stop = context.AfterFunc(ctx, func() {
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+		if _, active := pm.subscribers[sub]; active {
+			delete(pm.subscribers, sub)
+			sub.end(context.Cause(ctx))
+		}
+	})
+
+	if config.current {
+		for _, p := range pm.processes {
+			if spec.IsSatisfiedBy(&p) && !pm.deliver(sub, ProcessEvent{Kind: Entered, Process: p, Version: pm.version}) {
+				break
+			}
+		}
+	}
+	return sub
+}
+
+// publish notifies the subscribers of a change from before to after, either
+// of which is nil when the process was added or removed. The caller holds
+// the write lock and has already incremented the version.
+func (pm *ProcessManager) publish(before, after *Process) {
+	for sub := range pm.subscribers {
+		// Each check gets its own copy, so a specification cannot modify
+		// the stored process
+		matchedBefore, matchedAfter := false, false
+		if before != nil {
+			p := *before
+			matchedBefore = sub.spec.IsSatisfiedBy(&p)
+		}
+		if after != nil {
+			p := *after
+			matchedAfter = sub.spec.IsSatisfiedBy(&p)
+		}
+
+		event := ProcessEvent{Version: pm.version}
+		switch {
+		case !matchedBefore && matchedAfter:
+			event.Kind = Entered
+		case matchedBefore && !matchedAfter:
+			event.Kind = Left
+		case matchedBefore && matchedAfter && *before != *after:
+			event.Kind = Updated
+		default:
+			continue
+		}
+		if before != nil {
+			event.Previous = *before
+			event.Process = *before
+		}
+		if after != nil {
+			event.Process = *after
+		}
+		pm.deliver(sub, event)
+	}
+}
+
+// deliver sends event without blocking, ending the subscription with
+// ErrSlowConsumer if its buffer is full. The caller holds the write lock.
+func (pm *ProcessManager) deliver(sub *Subscription, event ProcessEvent) bool {
+	select {
+	case sub.events <- event:
+		return true
+	default:
+		delete(pm.subscribers, sub)
+		sub.stop()
+		sub.end(ErrSlowConsumer)
+		return false
+	}
+}
+
+// end records why the subscription ended and closes its channel. It is
+// called once, under the manager's write lock.
+func (s *Subscription) end(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+	close(s.events)
+}
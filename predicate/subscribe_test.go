@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// busySpecification matches running processes above 40% CPU.
+func busySpecification() ProcessSpecification {
+	return RunningSpecification().And(processLeaf(func(p *Process) bool { return p.CPUUsage > 40 }, "cpu_usage", ">", 40.0))
+}
+
+// drain reads the events that are already buffered.
+func drain(sub *Subscription) []string {
+	var events []string
+	for {
+		select {
+		case e, ok := <-sub.Events():
+			if !ok {
+				return append(events, "closed")
+			}
+			events = append(events, fmt.Sprintf("%s %s v%d", e.Kind, e.Process.Title, e.Version))
+		default:
+			return events
+		}
+	}
+}
+
+func TestSubscribeEvents(t *testing.T) {
+	pm := CreateProcessManager()
+	sub := pm.Subscribe(t.Context(), busySpecification())
+
+	pm.Update(2, func(p *Process) { p.CPUUsage = 50 })   // Python starts matching
+	pm.Update(2, func(p *Process) { p.CPUUsage = 60 })   // and changes while matching
+	pm.Update(6, func(p *Process) { p.Owner = "user3" }) // Node never matches
+	java, _ := pm.Get(4)
+	pm.Upsert(*java)                                        // Java matches but is unchanged
+	pm.Update(2, func(p *Process) { p.Status = "stopped" }) // Python stops matching
+	pm.Add(Process{ID: 7, Title: "Zig", Status: "running", CPUUsage: 90})
+	pm.Remove(4) // Java was matching
+
+	want := []string{"entered Python v7", "updated Python v8", "left Python v11", "entered Zig v12", "left Java v13"}
+	if got := drain(sub); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if sub.Err() != nil {
+		t.Errorf("expected an active subscription, got %v", sub.Err())
+	}
+}
+
+func TestSubscribeEventDetails(t *testing.T) {
+	pm := CreateProcessManager()
+	sub := pm.Subscribe(t.Context(), busySpecification())
+
+	pm.Update(4, func(p *Process) { p.CPUUsage = 80 })
+	pm.Remove(4)
+
+	updated, left := <-sub.Events(), <-sub.Events()
+	if updated.Previous.CPUUsage != 45.8 || updated.Process.CPUUsage != 80 {
+		t.Errorf("expected an update from 45.8 to 80, got %v", updated)
+	}
+	if left.Kind != Left || left.Process.CPUUsage != 80 {
+		t.Errorf("expected the removed process in a Left event, got %v", left)
+	}
+}
+
+func TestSubscribeWithCurrentMatches(t *testing.T) {
+	pm := CreateProcessManager()
+	sub := pm.Subscribe(t.Context(), RunningSpecification().And(OwnerSpecification("user1")), WithCurrentMatches())
+
+	pm.Update(3, func(p *Process) { p.Status = "running" })
+	want := []string{"entered Go v6", "entered Rust v6", "entered C++ v7"}
+	if got := drain(sub); !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSubscribeCancellation(t *testing.T) {
+	pm := CreateProcessManager()
+	ctx, cancel := context.WithCancel(t.Context())
+	sub := pm.Subscribe(ctx, RunningSpecification())
+
+	cancel()
+	for range sub.Events() {
+		t.Error("expected no events after cancellation")
+	}
+	if !errors.Is(sub.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", sub.Err())
+	}
+	pm.Update(3, func(p *Process) { p.Status = "running" }) // must not send on the closed channel
+
+	late := pm.Subscribe(ctx, RunningSpecification())
+	if _, ok := <-late.Events(); ok || !errors.Is(late.Err(), context.Canceled) {
+		t.Errorf("expected a subscription with a done context to end at once, got %v", late.Err())
+	}
+}
+
+func TestSubscribeSlowConsumer(t *testing.T) {
+	pm := CreateProcessManager()
+	slow := pm.Subscribe(t.Context(), RunningSpecification(), WithBuffer(2))
+	fast := pm.Subscribe(t.Context(), RunningSpecification(), WithBuffer(8))
+
+	for id := 10; id < 13; id++ {
+		pm.Add(Process{ID: id, Title: fmt.Sprint(id), Status: "running"})
+	}
+
+	if got := drain(slow); !slices.Equal(got, []string{"entered 10 v7", "entered 11 v8", "closed"}) {
+		t.Errorf("expected two events and a closed channel, got %v", got)
+	}
+	if !errors.Is(slow.Err(), ErrSlowConsumer) {
+		t.Errorf("expected ErrSlowConsumer, got %v", slow.Err())
+	}
+	if got := len(drain(fast)); got != 3 {
+		t.Errorf("expected the other subscriber to get all 3 events, got %d", got)
+	}
+
+	overflow := pm.Subscribe(t.Context(), RunningSpecification(), WithBuffer(3), WithCurrentMatches())
+	if got := drain(overflow); len(got) != 4 || got[3] != "closed" {
+		t.Errorf("expected current matches beyond the buffer to end the subscription, got %v", got)
+	}
+}
+
+func TestSubscribeConcurrentChanges(t *testing.T) {
+	pm := CreateProcessManager()
+	sub := pm.Subscribe(t.Context(), RunningSpecification(), WithBuffer(4096), WithCurrentMatches())
+
+	// Rebuild the matching set from the events alone
+	matching := map[int]bool{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for e := range sub.Events() {
+			switch e.Kind {
+			case Entered:
+				matching[e.Process.ID] = true
+			case Left:
+				delete(matching, e.Process.ID)
+			}
+			if e.Process.ID == -1 {
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := range 4 {
+		wg.Go(func() {
+			for i := range 100 {
+				id := 100 + w*100 + i
+				pm.Add(Process{ID: id, Status: "running"})
+				pm.Update(id%7+1, func(p *Process) { p.Status = []string{"running", "stopped"}[i%2] })
+				if i%3 == 0 {
+					pm.Remove(id)
+				}
+			}
+		})
+	}
+	wg.Wait()
+	pm.Add(Process{ID: -1, Status: "running"}) // marks the end of the stream
+	<-done
+
+	if sub.Err() != nil {
+		t.Fatalf("expected the subscription to keep up, got %v", sub.Err())
+	}
+	want := ids(pm.Find(ByStatus("running")))
+	got := make([]int, 0, len(matching))
+	for id := range matching {
+		got = append(got, id)
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("expected events to reproduce the %d running processes, got %d", len(want), len(got))
+	}
+}
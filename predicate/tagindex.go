@@ -0,0 +1,198 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+
+// Inverted tag index
+//
+// HasTag and friends scan every product's tags. A TagIndex keeps, for each
+// tag, the sorted positions of the products carrying it (its posting list),
+// so "has any of", "has all of" and tag counts are answered by merging
+// posting lists instead of testing the whole catalog.
+
+// tagArg and tagArgs mark the arguments of the leaves built by HasTag,
+// HasAnyTag and HasAllTags. TagIndex trusts a leaf only if it carries them,
+// so another predicate that happens to share a leaf name is scanned instead.
+type (
+	tagArg  string
+	tagArgs []string
+)
+
+// TagIndex is an inverted index from tag to products. It indexes the catalog
+// it was built from; build a new index after the catalog changes.
+type TagIndex struct {
+	products []Product
+	postings map[string][]int // positions in products, ascending
+}
+
+// NewTagIndex indexes the tags of products.
+func NewTagIndex(products []Product) *TagIndex {
+	x := &TagIndex{products: products, postings: make(map[string][]int)}
+	for pos, p := range products {
+		for _, tag := range p.Tags {
+			// Positions only grow, so a duplicate tag can only repeat the last one
+			if posting := x.postings[tag]; len(posting) == 0 || posting[len(posting)-1] != pos {
+				x.postings[tag] = append(posting, pos)
+			}
+		}
+	}
+	return x
+}
+
+// Count returns the number of products carrying tag.
+func (x *TagIndex) Count(tag string) int {
+	return len(x.postings[tag])
+}
+
+// Counts returns the number of products carrying each tag.
+func (x *TagIndex) Counts() map[string]int {
+	counts := make(map[string]int, len(x.postings))
+	for tag, posting := range x.postings {
+		counts[tag] = len(posting)
+	}
+	return counts
+}
+
+// WithAnyTag returns the products carrying at least one of tags, in catalog
+// order.
+func (x *TagIndex) WithAnyTag(tags ...string) []Product {
+	return x.at(x.union(tags))
+}
+
+// WithAllTags returns the products carrying every one of tags, in catalog
+// order. With no tags it returns every product.
+func (x *TagIndex) WithAllTags(tags ...string) []Product {
+	positions, ok := x.intersection(tags)
+	if !ok {
+		return slices.Clone(x.products)
+	}
+	return x.at(positions)
+}
+
+// Find returns the products that satisfy pred, in catalog order. Tag leaves
+// built by HasTag, HasAnyTag and HasAllTags, alone or combined with AND and
+// OR, are answered from posting lists and only the products they return are
+// tested against the full predicate; anything else scans the catalog.
+func (x *TagIndex) Find(pred predicate.Named[Product]) []Product {
+	positions, ok := x.candidates(pred.Node())
+	if !ok {
+		return predicate.Filter(x.products, pred.Test)
+	}
+	var result []Product
+	for _, pos := range positions {
+		if p := x.products[pos]; pred.Test(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// candidates returns the positions of a superset of the products that
+// satisfy n, or false if the posting lists cannot narrow it down. An AND
+// intersects all of its indexed operands; an OR needs every operand indexed.
+func (x *TagIndex) candidates(n predicate.Node) ([]int, bool) {
+	switch n.Op {
+	case predicate.OpLeaf:
+		if len(n.Args) != 1 {
+			return nil, false
+		}
+		switch arg := n.Args[0].(type) {
+		case tagArg:
+			if n.Name == "tag" {
+				return x.postings[string(arg)], true
+			}
+		case tagArgs:
+			switch n.Name {
+			case "tags_any":
+				return x.union(arg), true
+			case "tags_all":
+				return x.intersection(arg)
+			}
+		}
+	case predicate.OpAnd:
+		var result []int
+		found := false
+		for _, child := range n.Children {
+			positions, ok := x.candidates(child)
+			switch {
+			case !ok:
+			case !found:
+				result, found = positions, true
+			default:
+				result = intersectSorted(result, positions)
+			}
+		}
+		return result, found
+	case predicate.OpOr:
+		var union []int
+		for _, child := range n.Children {
+			positions, ok := x.candidates(child)
+			if !ok {
+				return nil, false
+			}
+			union = append(union, positions...)
+		}
+		slices.Sort(union)
+		return slices.Compact(union), true
+	}
+	return nil, false
+}
+
+func (x *TagIndex) union(tags []string) []int {
+	var union []int
+	for _, tag := range tags {
+		union = append(union, x.postings[tag]...)
+	}
+	slices.Sort(union)
+	return slices.Compact(union)
+}
+
+// intersection intersects the posting lists of tags, shortest first. It
+// reports false for no tags, which every product satisfies.
+func (x *TagIndex) intersection(tags []string) ([]int, bool) {
+	if len(tags) == 0 {
+		return nil, false
+	}
+	lists := make([][]int, len(tags))
+	for i, tag := range tags {
+		lists[i] = x.postings[tag]
+	}
+	slices.SortFunc(lists, func(a, b []int) int { return len(a) - len(b) })
+	result := lists[0]
+	for _, list := range lists[1:] {
+		if len(result) == 0 {
+			break
+		}
+		result = intersectSorted(result, list)
+	}
+	return result, true
+}
+
+func (x *TagIndex) at(positions []int) []Product {
+	result := make([]Product, len(positions))
+	for i, pos := range positions {
+		result[i] = x.products[pos]
+	}
+	return result
+}
+
+// intersectSorted returns the values present in both ascending lists.
+func intersectSorted(a, b []int) []int {
+	var result []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
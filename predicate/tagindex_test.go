@@ -0,0 +1,127 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/vdntruong/gopatterns/pkg/predicate"
+)
+
+func tagCatalog() []Product {
+	return []Product{
+		{ID: 1, Name: "Laptop", InStock: true, Tags: []string{"computer", "portable"}},
+		{ID: 2, Name: "Mouse", InStock: true, Tags: []string{"accessory", "wireless"}},
+		{ID: 3, Name: "Desk", Tags: []string{"office", "wooden"}},
+		{ID: 4, Name: "Chair", InStock: true, Tags: []string{"office", "ergonomic", "office"}},
+		{ID: 5, Name: "Headset", InStock: true, Tags: []string{"accessory", "wireless", "portable"}},
+		{ID: 6, Name: "Lamp"},
+	}
+}
+
+func names(products []Product) []string {
+	result := make([]string, len(products))
+	for i, p := range products {
+		result[i] = p.Name
+	}
+	return result
+}
+
+func TestTagIndexQueries(t *testing.T) {
+	index := NewTagIndex(tagCatalog())
+
+	if got := index.Count("office"); got != 2 {
+		t.Errorf("expected a duplicate tag to count once, got %d", got)
+	}
+	if got := index.Counts()["wireless"]; got != 2 {
+		t.Errorf("expected 2 wireless products, got %d", got)
+	}
+	if got := names(index.WithAnyTag("portable", "office")); !slices.Equal(got, []string{"Laptop", "Desk", "Chair", "Headset"}) {
+		t.Errorf("unexpected WithAnyTag result %v", got)
+	}
+	if got := names(index.WithAllTags("wireless", "portable")); !slices.Equal(got, []string{"Headset"}) {
+		t.Errorf("unexpected WithAllTags result %v", got)
+	}
+	if got := index.WithAllTags("wireless", "unknown"); len(got) != 0 {
+		t.Errorf("expected an unknown tag to match nothing, got %v", names(got))
+	}
+	if got := index.WithAllTags(); len(got) != 6 {
+		t.Errorf("expected no tags to match every product, got %v", names(got))
+	}
+}
+
+func TestTagIndexFindAgreesWithFilter(t *testing.T) {
+	catalog := tagCatalog()
+	index := NewTagIndex(catalog)
+
+	tests := []struct {
+		name    string
+		pred    predicate.Named[Product]
+		indexed bool
+	}{
+		{"single tag", HasTag("wireless"), true},
+		{"any", HasAnyTag("wooden", "ergonomic"), true},
+		{"all", HasAllTags("accessory", "portable"), true},
+		{"all of nothing", HasAllTags(), false},
+		{"and with other leaf", HasAnyTag("office", "portable").And(InStock()), true},
+		{"and of two tag leaves", HasTag("portable").And(HasTag("wireless")), true},
+		{"or of tag leaves", HasTag("wooden").Or(HasAllTags("wireless", "portable")), true},
+		{"or with other leaf", HasTag("wooden").Or(InStock()), false},
+		{"none", HasNoTags("office", "accessory"), false},
+		{"tag count", ByTagCount(3, 5), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := names(predicate.Filter(catalog, tt.pred.Test))
+			if got := names(index.Find(tt.pred)); !slices.Equal(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+			if _, ok := index.candidates(tt.pred.Node()); ok != tt.indexed {
+				t.Errorf("expected indexed=%v, got %v", tt.indexed, ok)
+			}
+		})
+	}
+}
+
+func TestTagPredicates(t *testing.T) {
+	catalog := tagCatalog()
+	tests := []struct {
+		pred predicate.Named[Product]
+		expr string
+		want []string
+	}{
+		{HasAnyTag("wooden", "ergonomic"), `tags HAS ANY ["wooden" "ergonomic"]`, []string{"Desk", "Chair"}},
+		{HasAllTags("office", "ergonomic"), `tags HAS ALL ["office" "ergonomic"]`, []string{"Chair"}},
+		{HasNoTags("office", "accessory"), `tags HAS NONE ["office" "accessory"]`, []string{"Laptop", "Lamp"}},
+		{ByTagCount(0, 0), "len(tags) BETWEEN 0 AND 0", []string{"Lamp"}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.pred.String(); got != tt.expr {
+			t.Errorf("expected %s, got %s", tt.expr, got)
+		}
+		if got := names(predicate.Filter(catalog, tt.pred.Test)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.expr, tt.want, got)
+		}
+	}
+}
+
+func TestTagIndexScansUnknownLeaves(t *testing.T) {
+	index := NewTagIndex(tagCatalog())
+	stocked := func(p Product) bool { return p.InStock }
+
+	// Leaves that share the index's names but not its arguments
+	for _, pred := range []predicate.Named[Product]{
+		predicate.Leaf("tag", stocked, 1),
+		predicate.Leaf("tags_any", stocked),
+		predicate.Leaf("tags_all", stocked, []string{"office"}),
+		HasTag("office").And(predicate.Leaf("tag", stocked, "office")),
+	} {
+		t.Run(pred.String(), func(t *testing.T) {
+			want := names(predicate.Filter(tagCatalog(), pred.Test))
+			if got := names(index.Find(pred)); !slices.Equal(got, want) {
+				t.Errorf("expected %v, got %v", want, got)
+			}
+		})
+	}
+}